@@ -1,6 +1,6 @@
 // Package api provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
 package api
 
 import (
@@ -20,16 +20,34 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List audit events
+	// (GET /audit-events)
+	ListAuditEvents(c *gin.Context, params ListAuditEventsParams)
+	// Returns a day off record by ID
+	// (GET /day-offs/{id})
+	GetDayOff(c *gin.Context, id int64)
+	// Get the org chart of a department
+	// (GET /departments/{department}/org-chart)
+	GetOrgChart(c *gin.Context, department GetOrgChartParamsDepartment, params GetOrgChartParams)
+	// Returns an employee import
+	// (GET /employee-imports/{id})
+	GetEmployeeImport(c *gin.Context, id int64)
 	// List employees
 	// (GET /employees)
 	ListEmployees(c *gin.Context, params ListEmployeesParams)
 	// Creates a new employee
 	// (POST /employees)
 	AddEmployee(c *gin.Context)
+	// Approve a pending day off request
+	// (POST /employees/day-offs/{id}/approve)
+	ApproveDayOff(c *gin.Context, id int64)
 	// Cancel a day off request
 	// (POST /employees/day-offs/{id}/cancel)
 	CancelDayOff(c *gin.Context, id int64)
-	// Deletes a employee by ID
+	// Reject a pending day off request
+	// (POST /employees/day-offs/{id}/reject)
+	RejectDayOff(c *gin.Context, id int64)
+	// Terminates a employee by ID
 	// (DELETE /employees/{id})
 	DeleteEmployee(c *gin.Context, id int64)
 	// Returns a employee by ID
@@ -44,9 +62,69 @@ type ServerInterface interface {
 	// Submit a day off request
 	// (POST /employees/{id}/day-offs)
 	SubmitDayOff(c *gin.Context, id int64)
+	// List direct reports
+	// (GET /employees/{id}/direct-reports)
+	ListDirectReports(c *gin.Context, id int64, params ListDirectReportsParams)
+	// List leave balances of an employee
+	// (GET /employees/{id}/leave-balances)
+	ListLeaveBalances(c *gin.Context, id int64, params ListLeaveBalancesParams)
+	// Get the management chain
+	// (GET /employees/{id}/management-chain)
+	GetManagementChain(c *gin.Context, id int64)
+	// Rehire a terminated employee
+	// (POST /employees/{id}/rehire)
+	RehireEmployee(c *gin.Context, id int64)
+	// Terminate the employment of an employee
+	// (POST /employees/{id}/terminate)
+	TerminateEmployee(c *gin.Context, id int64)
+	// Imports employees in bulk
+	// (POST /employees:import)
+	ImportEmployees(c *gin.Context, params ImportEmployeesParams)
+	// Detailed health report
+	// (GET /health)
+	GetHealth(c *gin.Context)
+	// List public holidays
+	// (GET /holidays)
+	ListHolidays(c *gin.Context, params ListHolidaysParams)
+	// Add a public holiday
+	// (POST /holidays)
+	CreateHoliday(c *gin.Context)
+	// Removes a public holiday
+	// (DELETE /holidays/{id})
+	DeleteHoliday(c *gin.Context, id int64)
+	// Returns a public holiday
+	// (GET /holidays/{id})
+	GetHoliday(c *gin.Context, id int64)
+	// Updates a public holiday
+	// (PUT /holidays/{id})
+	UpdateHoliday(c *gin.Context, id int64)
+	// Import public holidays from an iCalendar file
+	// (POST /holidays:import)
+	ImportHolidays(c *gin.Context, params ImportHolidaysParams)
+	// List leave types
+	// (GET /leave-types)
+	ListLeaveTypes(c *gin.Context, params ListLeaveTypesParams)
+	// Add a leave type
+	// (POST /leave-types)
+	CreateLeaveType(c *gin.Context)
+	// Removes a leave type
+	// (DELETE /leave-types/{id})
+	DeleteLeaveType(c *gin.Context, id int64)
+	// Returns a leave type
+	// (GET /leave-types/{id})
+	GetLeaveType(c *gin.Context, id int64)
+	// Updates a leave type
+	// (PUT /leave-types/{id})
+	UpdateLeaveType(c *gin.Context, id int64)
 
 	// (GET /liveness)
 	GetLiveness(c *gin.Context)
+	// Prometheus metrics
+	// (GET /metrics)
+	GetMetrics(c *gin.Context)
+	// Readiness probe
+	// (GET /readiness)
+	GetReadiness(c *gin.Context)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -58,11 +136,178 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// ListAuditEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditEvents(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEventsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageSize: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "entityType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entityType", c.Request.URL.Query(), &params.EntityType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter entityType: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "entityID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entityID", c.Request.URL.Query(), &params.EntityID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter entityID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "actorID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actorID", c.Request.URL.Query(), &params.ActorID)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actorID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListAuditEvents(c, params)
+}
+
+// GetDayOff operation middleware
+func (siw *ServerInterfaceWrapper) GetDayOff(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetDayOff(c, id)
+}
+
+// GetOrgChart operation middleware
+func (siw *ServerInterfaceWrapper) GetOrgChart(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "department" -------------
+	var department GetOrgChartParamsDepartment
+
+	err = runtime.BindStyledParameterWithOptions("simple", "department", c.Param("department"), &department, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter department: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOrgChartParams
+
+	// ------------- Optional query parameter "includeTerminated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeTerminated", c.Request.URL.Query(), &params.IncludeTerminated)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter includeTerminated: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOrgChart(c, department, params)
+}
+
+// GetEmployeeImport operation middleware
+func (siw *ServerInterfaceWrapper) GetEmployeeImport(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetEmployeeImport(c, id)
+}
+
 // ListEmployees operation middleware
 func (siw *ServerInterfaceWrapper) ListEmployees(c *gin.Context) {
 
 	var err error
 
+	c.Set(BearerAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params ListEmployeesParams
 
@@ -106,6 +351,14 @@ func (siw *ServerInterfaceWrapper) ListEmployees(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "includeTerminated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeTerminated", c.Request.URL.Query(), &params.IncludeTerminated)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter includeTerminated: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -119,6 +372,8 @@ func (siw *ServerInterfaceWrapper) ListEmployees(c *gin.Context) {
 // AddEmployee operation middleware
 func (siw *ServerInterfaceWrapper) AddEmployee(c *gin.Context) {
 
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -129,6 +384,32 @@ func (siw *ServerInterfaceWrapper) AddEmployee(c *gin.Context) {
 	siw.Handler.AddEmployee(c)
 }
 
+// ApproveDayOff operation middleware
+func (siw *ServerInterfaceWrapper) ApproveDayOff(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"manager", "hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ApproveDayOff(c, id)
+}
+
 // CancelDayOff operation middleware
 func (siw *ServerInterfaceWrapper) CancelDayOff(c *gin.Context) {
 
@@ -143,6 +424,8 @@ func (siw *ServerInterfaceWrapper) CancelDayOff(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -153,6 +436,32 @@ func (siw *ServerInterfaceWrapper) CancelDayOff(c *gin.Context) {
 	siw.Handler.CancelDayOff(c, id)
 }
 
+// RejectDayOff operation middleware
+func (siw *ServerInterfaceWrapper) RejectDayOff(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"manager", "hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RejectDayOff(c, id)
+}
+
 // DeleteEmployee operation middleware
 func (siw *ServerInterfaceWrapper) DeleteEmployee(c *gin.Context) {
 
@@ -167,6 +476,8 @@ func (siw *ServerInterfaceWrapper) DeleteEmployee(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -191,6 +502,8 @@ func (siw *ServerInterfaceWrapper) FindEmployeeByID(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -215,6 +528,8 @@ func (siw *ServerInterfaceWrapper) UpdateEmployee(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -239,6 +554,8 @@ func (siw *ServerInterfaceWrapper) ListDayOffs(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params ListDayOffsParams
 
@@ -290,6 +607,22 @@ func (siw *ServerInterfaceWrapper) ListDayOffs(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// ------------- Optional query parameter "filters" -------------
 
 	err = runtime.BindQueryParameter("deepObject", true, false, "filters", c.Request.URL.Query(), &params.Filters)
@@ -322,6 +655,8 @@ func (siw *ServerInterfaceWrapper) SubmitDayOff(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -332,8 +667,32 @@ func (siw *ServerInterfaceWrapper) SubmitDayOff(c *gin.Context) {
 	siw.Handler.SubmitDayOff(c, id)
 }
 
-// GetLiveness operation middleware
-func (siw *ServerInterfaceWrapper) GetLiveness(c *gin.Context) {
+// ListDirectReports operation middleware
+func (siw *ServerInterfaceWrapper) ListDirectReports(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDirectReportsParams
+
+	// ------------- Optional query parameter "includeTerminated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeTerminated", c.Request.URL.Query(), &params.IncludeTerminated)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter includeTerminated: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -342,7 +701,513 @@ func (siw *ServerInterfaceWrapper) GetLiveness(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetLiveness(c)
+	siw.Handler.ListDirectReports(c, id, params)
+}
+
+// ListLeaveBalances operation middleware
+func (siw *ServerInterfaceWrapper) ListLeaveBalances(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListLeaveBalancesParams
+
+	// ------------- Optional query parameter "year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year", c.Request.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter year: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListLeaveBalances(c, id, params)
+}
+
+// GetManagementChain operation middleware
+func (siw *ServerInterfaceWrapper) GetManagementChain(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetManagementChain(c, id)
+}
+
+// RehireEmployee operation middleware
+func (siw *ServerInterfaceWrapper) RehireEmployee(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RehireEmployee(c, id)
+}
+
+// TerminateEmployee operation middleware
+func (siw *ServerInterfaceWrapper) TerminateEmployee(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.TerminateEmployee(c, id)
+}
+
+// ImportEmployees operation middleware
+func (siw *ServerInterfaceWrapper) ImportEmployees(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportEmployeesParams
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", c.Request.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dryRun: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportEmployees(c, params)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetHealth(c)
+}
+
+// ListHolidays operation middleware
+func (siw *ServerInterfaceWrapper) ListHolidays(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListHolidaysParams
+
+	// ------------- Optional query parameter "region" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "region", c.Request.URL.Query(), &params.Region)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter region: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageSize: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListHolidays(c, params)
+}
+
+// CreateHoliday operation middleware
+func (siw *ServerInterfaceWrapper) CreateHoliday(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateHoliday(c)
+}
+
+// DeleteHoliday operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHoliday(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteHoliday(c, id)
+}
+
+// GetHoliday operation middleware
+func (siw *ServerInterfaceWrapper) GetHoliday(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetHoliday(c, id)
+}
+
+// UpdateHoliday operation middleware
+func (siw *ServerInterfaceWrapper) UpdateHoliday(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateHoliday(c, id)
+}
+
+// ImportHolidays operation middleware
+func (siw *ServerInterfaceWrapper) ImportHolidays(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportHolidaysParams
+
+	// ------------- Required query parameter "region" -------------
+
+	if paramValue := c.Query("region"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument region is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "region", c.Request.URL.Query(), &params.Region)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter region: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportHolidays(c, params)
+}
+
+// ListLeaveTypes operation middleware
+func (siw *ServerInterfaceWrapper) ListLeaveTypes(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListLeaveTypesParams
+
+	// ------------- Optional query parameter "includeInactive" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeInactive", c.Request.URL.Query(), &params.IncludeInactive)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter includeInactive: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListLeaveTypes(c, params)
+}
+
+// CreateLeaveType operation middleware
+func (siw *ServerInterfaceWrapper) CreateLeaveType(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateLeaveType(c)
+}
+
+// DeleteLeaveType operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLeaveType(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteLeaveType(c, id)
+}
+
+// GetLeaveType operation middleware
+func (siw *ServerInterfaceWrapper) GetLeaveType(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLeaveType(c, id)
+}
+
+// UpdateLeaveType operation middleware
+func (siw *ServerInterfaceWrapper) UpdateLeaveType(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"hr_admin"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateLeaveType(c, id)
+}
+
+// GetLiveness operation middleware
+func (siw *ServerInterfaceWrapper) GetLiveness(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLiveness(c)
+}
+
+// GetMetrics operation middleware
+func (siw *ServerInterfaceWrapper) GetMetrics(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMetrics(c)
+}
+
+// GetReadiness operation middleware
+func (siw *ServerInterfaceWrapper) GetReadiness(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReadiness(c)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -372,46 +1237,159 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/audit-events", wrapper.ListAuditEvents)
+	router.GET(options.BaseURL+"/day-offs/:id", wrapper.GetDayOff)
+	router.GET(options.BaseURL+"/departments/:department/org-chart", wrapper.GetOrgChart)
+	router.GET(options.BaseURL+"/employee-imports/:id", wrapper.GetEmployeeImport)
 	router.GET(options.BaseURL+"/employees", wrapper.ListEmployees)
 	router.POST(options.BaseURL+"/employees", wrapper.AddEmployee)
+	router.POST(options.BaseURL+"/employees/day-offs/:id/approve", wrapper.ApproveDayOff)
 	router.POST(options.BaseURL+"/employees/day-offs/:id/cancel", wrapper.CancelDayOff)
+	router.POST(options.BaseURL+"/employees/day-offs/:id/reject", wrapper.RejectDayOff)
 	router.DELETE(options.BaseURL+"/employees/:id", wrapper.DeleteEmployee)
 	router.GET(options.BaseURL+"/employees/:id", wrapper.FindEmployeeByID)
 	router.PUT(options.BaseURL+"/employees/:id", wrapper.UpdateEmployee)
 	router.GET(options.BaseURL+"/employees/:id/day-offs", wrapper.ListDayOffs)
 	router.POST(options.BaseURL+"/employees/:id/day-offs", wrapper.SubmitDayOff)
+	router.GET(options.BaseURL+"/employees/:id/direct-reports", wrapper.ListDirectReports)
+	router.GET(options.BaseURL+"/employees/:id/leave-balances", wrapper.ListLeaveBalances)
+	router.GET(options.BaseURL+"/employees/:id/management-chain", wrapper.GetManagementChain)
+	router.POST(options.BaseURL+"/employees/:id/rehire", wrapper.RehireEmployee)
+	router.POST(options.BaseURL+"/employees/:id/terminate", wrapper.TerminateEmployee)
+	router.POST(options.BaseURL+"/employees:import", wrapper.ImportEmployees)
+	router.GET(options.BaseURL+"/health", wrapper.GetHealth)
+	router.GET(options.BaseURL+"/holidays", wrapper.ListHolidays)
+	router.POST(options.BaseURL+"/holidays", wrapper.CreateHoliday)
+	router.DELETE(options.BaseURL+"/holidays/:id", wrapper.DeleteHoliday)
+	router.GET(options.BaseURL+"/holidays/:id", wrapper.GetHoliday)
+	router.PUT(options.BaseURL+"/holidays/:id", wrapper.UpdateHoliday)
+	router.POST(options.BaseURL+"/holidays:import", wrapper.ImportHolidays)
+	router.GET(options.BaseURL+"/leave-types", wrapper.ListLeaveTypes)
+	router.POST(options.BaseURL+"/leave-types", wrapper.CreateLeaveType)
+	router.DELETE(options.BaseURL+"/leave-types/:id", wrapper.DeleteLeaveType)
+	router.GET(options.BaseURL+"/leave-types/:id", wrapper.GetLeaveType)
+	router.PUT(options.BaseURL+"/leave-types/:id", wrapper.UpdateLeaveType)
 	router.GET(options.BaseURL+"/liveness", wrapper.GetLiveness)
+	router.GET(options.BaseURL+"/metrics", wrapper.GetMetrics)
+	router.GET(options.BaseURL+"/readiness", wrapper.GetReadiness)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xZX2/bNhD/KgS3hw1QY7crisJAH9q47bIWdZCkT50fGPFks6NIlaScaoG/+8DTf4ty",
-	"miLZjC5PiUXy/v1+d7yTrmms00wrUM7S2TW18RpShv/OWbFIkjOIteH+d2Z0BsYJwFWOqxdFBv4XqDyl",
-	"s0/09GJBI2pF/BeRwDZAI5oxA8ox2Ty4BOP/S0E5uoyoQxHUOiPUim4jCmkmdQFwMkc9YGMjMie0ojP6",
-	"UYkvORDBiU6IWwOpN9OIJtqkzNEZFco9e0ojmgolUm/X40aLUA5WYFCN4hciReubk5w5eOT804BdBpj1",
-	"RlwPl6xjxt1GGor7kgsD3Iet43LUjWyjtKuiNb2Nnr78DLHzpryuAzK7pkzKRUJnn67pzwYSOqM/TVq0",
-	"JxXUkw9w1RzaRrs4C36PKOyEQXC63C69E8ZoM+RcrDkMrcHNBNdCOKdgLVuNnquXb4Kokl9v91a+F9aV",
-	"SWLPwGZaWQjliWMYRwcpPtgHRS/lto1JzBhW+N9Z0JHj3PgUI36VqDy9BHMj+/3ec/F3QNoHFOCRRZNJ",
-	"BgYl3yjSacfksc6VGwq98GuVbV60QRftXr5Mb+QLxranuApRx79QjnjgasrfFXSdFHqA7X5g6xaqAViM",
-	"cwPWBsszh4wZhxdO5646ZxK8K2+EYioWTNKIzsGKlS+3r9VKKACUENG3oMAwSViSMGHsyLXFhOzV//JJ",
-	"YKuEDcigpYqlIWxZCoFSOzit1aVmhs+ZG15Eof3ZWisoiRO+1phkpvBL+9CNqBNOQkDCDu7oXNSEpas9",
-	"avBrtPbd6YFYa6wj6YvxqVarISt6F/N+69qtQ+75vUIlegjNS2LBCLAenpenJ5Yk2pDfz8h5YbFqNMGh",
-	"vYcbMLYU8PhoejRF8DJQLBN0Rn/DRz4Z3Bq9mNSg468VBDL1DJwRsAHCfNYLxRxwIoV13rDmOLkSbk0S",
-	"IR0ymzDFidXG+f81ivLG+QAy/+OE01m/WJb9HEvBgbHYWQiv/UsOiFhJ3zqZy9JY2pqwXDqsQPv7gXGB",
-	"WBbCQqcRTdnXSup0+n06fBxeFT0NdaXYIW6PiV2SLgNNxLiyheHI/IBHlNkY+7xSf/nLAz6ios+Fd1A8",
-	"2jCZA8l8tUJKtphXNZz8Akero6hasJ9ap5YvOsXvz3w6ffKs3uStX774Q6/Vr968r5nEjsyZHKKgn9XB",
-	"npeMc+EtZfK0l6uD8jO4AKwrMJU4QLaoni59Fpc3OEp5Mp2WraJyVb1nWSZFjISefK46+NaafXd6uE/A",
-	"atCP+OJdec1U8N2R+rILDqjLFXzNIPYpDtWeiNo8TbFaY8a2OY+FXttAzTg2wBxYwoiCq+YAEQovGovF",
-	"6ojM89J+4MSDagkzQJiU+gr4oFi85Px1e0H58grWvdK8uLOg9KaVYWgaL5wmjHP/p3WGdiu+J+32Hsmz",
-	"z8rD4kuYB7ipvXkmnBWPdJLYybXg20nMVFz2MDW3+kQ4xvVynhm5NPz11lYKwQfodIvGoN8clPXl9/Nt",
-	"Z8pE0yVuPhub+Xenw+GZcBfxTfzrozdnBdGJb77RO1LpAk5sHsdgbZJLWRwUodBCwgjvm75LKc+k0l8J",
-	"LtD3ls89Ma1QK9k2v+SSWeBEl5XqZE5s7p0MFKQ5iujUpB0m9hWezLvtki8elWnR/VG2B//TsSDwQ8J3",
-	"3uDSAlKQk7m3caw7zY3qH6gRbMDFl1999N4I1dwnrwrccAv87ga1vb3k8uECGYG35kOWB/jwMeM3dx4D",
-	"NpSnvi+X/y0u/KcNT44B6ofxoecJUrZl4Fi/g21O3fR05u7hdFy9Az44QkY/9nTeTsv9byPlzNx91vmi",
-	"cncjOo7jt5jRgzpqI98YndIg0uF3dzcKvNC3FXcX7xCaOC9fnF4s/jfvCHY/AgXK0fvqVVzbEZdv0g/t",
-	"tUHAvvCAd55fpsKNDXg/5lXc/zz3LePc43vUvX9KtIiPO+ApsWTQ2JQoxQZU9UEnePO+Bfe+3nOP+Y3f",
-	"FQLO1fYR08l6dBDMpk6D3Eg6o2vnstlkInXM5FpbN3s+fT6l2+X2nwAAAP//Imvhyn0hAAA=",
+	"H4sIAAAAAAAC/+x9bXPcuNHgX0Hx8iGpoqSx9+USbW1daaXd2Jtd2yXJ67rb6LwQ2TODiANMAFDjiUv/",
+	"/aluACRIYjgjW7Jlx59sDUmg0d3odzTeZoVaLJUEaU12+DYzxRwWnP57VJfC/ngN0uJfS62WoK0AesYL",
+	"q/TTE/xvCabQYmmFktlh9qswRsgZmyrNijmXMzBswUtgl2tm58DM2lhYZHk2VXrBbXaYCWm//TrLM7te",
+	"gvsTZqCzm9xNcqoqwGn8Y2O1kDN86kcnaMpS4Py8etGB8k8aptlh9r8O2jUe+AUe/CSgKo9pDBytuwr3",
+	"e8mm+JJB2CVfQAukuvwXFBa/A2mFXTtM7LAk9/o5/f42A1kvssPfM1gsK7UGnKDk69dqOs3ybK4qUfJ1",
+	"lmcV8Gt4TYNd5ENEiHLHyVVR1FpDeWQ7H5Tcwp4V8frawRGd3GGlhbfQwC2+Xi9L958SKqD/FFwWUGV5",
+	"xpdLra7xJw2ErDyzoBdCug80zIVOrecGn/27FhpKnEqUWQfwDgoj9MeQtrxxkaDYCbf8kht4oVR1Zrk1",
+	"Q+4WZYfnIhQK+dJseLTgb56WFRxXysCuFPHfnIvFrb/7RUzBvsN3z5cgj5WUUCCuTHotapeXVlzYY1VL",
+	"u+Pk+P5J7Wj0q9npox4zJMAfwhqIlDsyxnAOYOhTLUWRFLbTfLV+Pp2eQqF0mRCY1vJivgBpX57+MhSb",
+	"vwh5xaxinJl6uVTaogwtVVHjFzkLSEBJZNQCGIkEhjCYWJbWWnh4Qc7sPDt8PPn6r4ld7XZpRWg4BW7c",
+	"9tbAy+eyWmeHVtew+bMgQbpLeDUHSSIegQVj2YobthJ2Xmq+kgz1Qfg8BjmWPrsD8MN6CMDTkqkpQRDk",
+	"KVvNlQcBVh0IYkBTymgDJBE3l0TuIMl76kOVgLBwyXhhxXVMr5wZAHZAP+wFAkYU+2aSWHfpWfaEr00C",
+	"80pfEb/wtQko8GvLWQllXVjUZVot6JGD5ZJXiIwOKVR9WY3QQdaLS9AxPE9UrUcAmuPjAUTeElgpfcVQ",
+	"G5d1Bd8xzua8muIamDDu//7Dkotq7cZ6N2gDO6TslZdS/LsGJga8k+KKhZBigSrwUVq3lyg2dtetuMgT",
+	"nuDkU4cqQwAtlJaITKXpTz61oKVSMgC8aqmPPxnLtUU49tn5HNyfjMuSgSwZQmIY14BfSmbAotCJR4lI",
+	"xm1Dkn1StU75H/2a5dmLX29jimzfTboRQoMxNVwLWB2rxcJbolulhPti1M7ZdYykjbtB0HiLp0RKOZvn",
+	"LuRMQ8/d+cpYbmsTG2xLkCU+bMyysrHL6L+xZG5EdnaxEb5orvpyIax9H2SnLL5oy3ZEbcMpMWLardes",
+	"vQtYX2D1BOqYNkcuGHLAOVHVcQiKK9qYNe4pKwqOdC94VYHO8p4ZULRsPNzypOFXuDEdaXA/8oh7hngb",
+	"gg1IapDF+ngOxdXQDgGtlR5Ov5o7oVw23+OySrWSOVOyWjMNS6Wts0BmYJ8Ar+w8xXwVt/h5z8BrhPVA",
+	"OLekH8IEdg5O6glpLPIoQoXstHZQiWkC6nrZznOpVAVcpncFvYhr3O6GNGzV/BivNMVAPwY9MljXkWzl",
+	"BjfMAMigEx3X7LMzXnG9zhkvSw3GkPxezpUE5hBHIlw5/ma1rPCd9vvAkWGWHP8SmpVCQ2HZgks+A83+",
+	"bGiW4eCI2r+gFOOSPTllvFwIuf9POWBmD11Sapew5NoGXg8YP+MV2Ts/CcllIXiVIcsaMcPBf5QzIQG0",
+	"E1R/BwmaV4xPp1xok1Q3sOCi6vCZ+yX5KuIC4Tkb8IGz0WIHtRzRbvdmP1RwDVUSmZ5iO+oiOxem/ctt",
+	"XMOs2gaRrKuK4y7dqIsoCDIA4RlfQGL1g1Uoeam4Lk+4Heqy1PvEk8+coEgbBjMfl+BV9XyaHf4+HvE5",
+	"de/fXORDz0VDB3wyhwztG+bjMJEdi8PQDpTKsgI9SyhxJ5NhTaKGNlYiOKaknVdr5jeekOzZ+UlMhUkK",
+	"64ErSV3tiLvom9a/6wKDGpldCyMuKwhWYCuXZNlsfZOcQNhkXC6lzH3sLOzNmA86giKMGrbCRSRGny6Q",
+	"jYf6zIWibhXSKvX6tI5tzUhJbFCPr+ZrxtmUC3QdBYHCjFXLJZQ502plnFlOOFxqVYAxUDIlwbAVeEZx",
+	"n5HmGIBE0yYcqR/p98B5fn6cz2tl+lVoY9mjyWRCPHkFS8SksLDYGgN1SD1VK5onaw0KrjVf499uylO1",
+	"2hADmgopzPx2+N85XhkwFqbvWUyIdk/+nOLNpV4zXUun/Igq5MSsVF2VbE5OL4AM3ySnbIg3Muc1R4FA",
+	"s3LZqF9hUe3yAARbaWEtyJwZxaZcZxts+54q0rWUTv+ZuigAShcsISokdZJVlleb6JPajI0l47dBPER/",
+	"/T0SdNihYdo82oNJQyhsqb4lXCZ0yZlFDcQWvJgLCXsaeEk/0FwMv8kZ7M/2G0n1Wir7eqpqmdxXJVgu",
+	"qgQhKfrPSMww/xKxkKctCvhmeTttJVrlCY2U2kcLMIbPEgt+Ui+4ZL11hrdHfbveOOfnL5h7SGjaHkxt",
+	"GMG/Hia9CDTzq9lIuQFslC0Ztw9CbJBepV/cijVMQXsjZTBuhLxxbeMASK4oTvcMVkTCewj4b7yqIZLs",
+	"i9oSa+QMTSUncqACS2Hnmzy7hKnSsGkc93TTQLSH3EApv877W0NaoJf3zjmwvreYmhk9VmHTNn6USdk6",
+	"1SDr0o9t7MzqJcw0L4OrTHpQLd3SW09Q+DgXuXd5z+XzA2z2/vLsGrQRSm7nuWYbhS8alHVDFJ5SPayl",
+	"BOYTn/gbELvc1fp75zBcMPDjDMI335CBGv5+lI9Z4rvY3ymt5EfIw5IIkBHktOZgP/JeSx83jW32glcg",
+	"S67Rz16hTYA/ejuuFCXlCPC3xTBg442FpPVTS5do3PSYsqMJiYhRp44vUbmYxpyXGAd3S2QoH2pZAvrh",
+	"iiIh3ST0JsHe2jcBgBjSFFJ7duCdSvxnsAo2PPOjrt9f8OeZVom43DMfwJgGEzB3AXCKpFn2iAy2xmvD",
+	"X/G947Pf2Bx4CXo7cnHaoYJJ4PQXdAZ/8EmWxFaOs0eDxVFau4Iyne/BX9lMc/I7pz4vsAYyMneKuC24",
+	"QCszjL7DN7UZBcbyKxfE8oFmwnMTD/dK3+wCXg/dndBvBysRTP0lbSRHwPeglgXDP7SyKa8rG+RjL9Ii",
+	"3Ysu69r4WoEAGF+jDMzUsMvaYqqRScUqJTHWdtmYPrHjETmeXMqaVy2KPSCTfIz2S9BEd8Q8Z9OKW+Zx",
+	"5P3pIboX/I0LNXz17bfJwENL87SF/grFp18p05TtNqw2FMps0NDSrJfZ3KJIYuiHJq5aNZxerZ18Ryp4",
+	"bOwzA1IoLeyazcj7C1KdviBdYEBfi8KFN1wq9BqqnFXiCtiL8+ffORzSgIa1JMlRcu1pCuwLl+T2QQzk",
+	"dRw9TpA1YCABKm7vNlO24G+OlTRQ1MiM2xnmV2Vsk90LOpEzLNKqoqTshPgYGVY422U8JrUQ8pmyooAd",
+	"OfYS7Ap69QGBCC6A4VkKIbGK8apSq/Cmc82sctVQWwE7c0SmYNtW5OA7MWdEVjoOTts43rze/fSBnkvn",
+	"Ki5BC1VuhS1hXT2abN8US54OPOPvPpkvTDcWOeNCIoZDlp+iEMIOVkNbRNWWKQlJseRlsTkiWc6r0Vw1",
+	"CgZFgEiAIP8xhK+hVQYrXyaypp+bLN347E3xzMj8i9pYVmEdTbKIBjdutwhnOGPKMvWq3pteRIskYAlc",
+	"deVZUisJY9syS3MKZol7O2n6c/x3p1hEO2IqFLFMxiGOa60RS/jU54G2Jizw3TPxHxizwwhgUlRLF9EY",
+	"H5JiUU1RWS/pis+aHFWjfEbTGpOtBh1htjOxR1G0vk2kc0niuyJbp4DsC+Huk3DBI7kr0oXxvpDtXsnm",
+	"nf+7opof7rMmWshefkSqxZ7wXZEuHjNFv27J3w65JnKft6dQOnVJ3uUmwEcXj77Qna4cBxwuO0WzFFxR",
+	"SGYIzKdU1PGlYuJLxURSQO5WnNCrS4ixlzf7oIHtloULz/XseM61fabKxC6DaP/talh4vtxZUnQg2CYs",
+	"IjYM86RExwslZ8PVjOVxhukS/2pq+FPgpZBe/HzoTFciG49R+SzPMMfs/r97oaCHN73KsA17kunsOfvq",
+	"0bffUgLXhWlor+g1Uxpd5VJgvY6SPghx/gp/f3m2d3xEetha0DjO///9aO//Xbx9fPPnPfzfZO9vF28f",
+	"5V/d/OX//ClZRYrBJIxXnSGyHC4vgWvQR7Wdt3/9FMTPz6/Os75E+PnVOUOR72MK7MnZ42++RfBO6T8F",
+	"13qN7vgfTeJelH9QCOgPrSr4gxUVFwuzz/C8n4vrteWLoWIRX5/r11SV9J07TlioJRh/1qI5AcYqYaxP",
+	"AdBoGE2CkllFFZJMWFfOSNxBEQBaYIucubXL7OaGDnxNVaKEkxnQwk189OKpC1A9OWVndLrRNALhMOv8",
+	"2CQVs0f7k/1JOGnFlyI7zL6in4iOLmx1wNGP34PrcDZzBsn4h621NN46hzJCWxNSCxklxF97EqVJQ5uc",
+	"SVi5tLymSuMGk0/L7DCrulEKglHzBVjQhnSIQED+XQNJSafygnXoNmAnCvdo3L69yTcPSHZmetBJFNsO",
+	"UbVbz9E5W9jOchfnNMenfHrSmfBWlaM3eZ8nnp4kDygsQePA/lRCoH6WJ+EKJ3zvECyqOHTsjNk4pZva",
+	"CmGYrxZLgTLVapGGY6TObHz6Jsw7PrNVt5+XTk04U5927ePJJKMcqrTecObLZSUKQv7Bv3xtZjvJqPG/",
+	"IV5IoqonovA1v1xnu/udckeg+JLB4cS1hDdLd/gF/DutiiFxESuX37Mg0LMLRJ2pFwuyBMl7Yryzips8",
+	"Oyj5eg+TbAdvRXmzUSr+lq5r3VAJ36927Yq/GfhA3wbBhwK7ZZk4PlwGx+Bdt9B98lI33Dik4/kcggbx",
+	"yuUBsFHDHUHt8R6MmAp9euJZpbHQzcHb9o+bA6VnewWaxlu1qnNfQi7BavB2WTvaPmuCiShkDTRcJQxT",
+	"tTWihHBIxX9C1o1Wypr9FLMFu30ndut4IZvZ7l589aGIPaqMYoRZBjFWWAVTZ5AhC3C53iBvhSyquoTz",
+	"9hxGUtlPeWUgkb553+1yF27VgJGf65lHiZr2GOEhbai/gyOQisHlHWBxTwWy7rnqqS1i+DSEUly6dEaH",
+	"mZyx7r53JdTCGizWaUtuB3uidwzgsxLEvbVtEMXCP/0ENXkjq6ODb2E5MU+NOjlawDUwjhFyJxqck6em",
+	"saRBz3MqKksyjFjLeNHtKkRN0rdpBPhn7NkgHn5YJ72aXhiso1HikNfuPg1O9ly7errEijJuiqhexv2F",
+	"BN9Jx/wD1nvXVFG9RL1ErndL81CR9GeMkOT+gfm9XdTF95Ga+2c9mTz+NryE0F98/7Oay78geG+WFcXu",
+	"nORIOibuw84qN0Wnhic3hvGndeVUOiyfh1+TGpY4/1NUsNt8m2FeNqVQ//GQ9OYvHVogZEtlUpXJGrgF",
+	"NFglrCI5KKO2WPvspHbwQ0nFvi4Q5sNXA9nFy/LHOGxLhTA/qHJ9Z0iJM0UJ1DSrsAqPKgdvq+nx1dW+",
+	"Nx9Ahz5Yfrm91kwzTE9jdt3hg9B06/Btw4U9lnEv3KM/mzad7p41Ow0abm5u+kDefETX+aRxSX2lY2i6",
+	"8ZD50LutWb6RI11hGxlhvsy77C50nDl9a7iNvOmefyKs2UtOJftp9U/RjGeOEmMMk0cPi8/P6WiNbx2T",
+	"MzTElG6Yo6nebTrK5A84oHRM6+jEk3bgad/bcCNPu+dfxO0HFbdNY6NPW9ye0jJ2l7YhEuN7cQ6bCYQ3",
+	"XSUGXINmGhaomHJ/kBUdZQms6YMSjnS03VOwagPjWFiMv89eGl8qHz6IcrBWhZAs7inrDl247k0u+9rd",
+	"KQ7qyJ7t7ZVUhiu2P5sOpPe2qTrc/XXqXGoFD53rNvHaeUtx3qLVx9Lz8Rh5/AF3fS/aAyZPTwaUngrZ",
+	"+C0/uMznLWh9NxT+6MG+h+/Ypsgb+GFZJ/jhJZ023eLhDrjBnVF9t33/oXjhozrWDkFdNH7xrd9P3LWs",
+	"usmvJusu2HpRXHoYPfaHQB4c5+afd/S6maHXCNKfwYx+i86j3l0IG4n77jHs566logtVNyfUlezVxPg6",
+	"3yR8YYE/jRbHZO8JTFwnsws05+oOYVHXoCu+XIZz+prLGYQ+xh4gXwrpq9rwCCLBAiUDrivhizsELkxU",
+	"FWv7K92+0OiO4K+XFC5FqCgY756O49e+J1p/fMOp3MUWczBI2uZ8K8Lh2wY11drpDEqziy6+f3H+vJs5",
+	"cSNcfB8CXXeeP/EJibEmChtacEcFj/fcK3eH9qypFM9951T6hxQTavUXn0ntHet/cGmWBHzpZMt5dMxc",
+	"GLYAbmrdHt4Px+GtaM9hdNqR583B6BXAFcjStC0DsJUKu9TAr9rihaYXwZZu6/usOa6spkyqLiQ4QGC0",
+	"oaHsjkpvCiV9nmbyMPKzzex99NGiTs1Rdkat+4yZ1lW1fkh76Iwg3B7idLYvlUjuRYdOthbKweZCOHw8",
+	"E9cgY6ZM2NI0qa8aeogW9eeUjH/Pk82JHUHUa87P9Q+4PTht0gE3uQncLR1eeO+4CXyTopzVBkof9PTd",
+	"iZyeWELTKsjZYJg04a6NDPu/1Kom6kISNZqJnANghT+LrKRTHIidmhqUVEpd+YZXdg4LdqnUlUFlE3Wj",
+	"SEVgq/5Z3Ye/AT2khLucee4yoQ4hoMif001tQf+oBaid/2//e/KBY4ObD0unbLbYrnj4m60agBtVAyb3",
+	"ntMeyKxYqy3kTrvPfdS2TO7qnLw1y3onAJxThg+sWoaPlZ5xKf4TTskMilJ/bSA8JgA/tzj2nSuIFmGM",
+	"SPqQuTbUQy96MCd51d9mN5IGxuefY5C765H3Dqz3ey63fb4ItW2awN1y1iq3qH09hUX22VnT200DRXiM",
+	"28vC5pGbxYtC11FTxFgDoBmKDfuc6tsaQunUZqTLYD9OUcZYOP6U+Kx8SBvqXcrFcRGMs/Y6jnFN0by3",
+	"eQM2r3z+e3DkKq3ETRI9o4K3OzTemZTu9wYlq7gFY/fZSWh3GXp+0vYV7nhT+uK//VvvvD7ID3n3naf4",
+	"9VMuBoiUMynAUavtULRXdIxWQDdfOAnOqf/uVFRADg77+ez5M0YmBM4Xtw92FDf77Mdr0Gs6MSSi6xja",
+	"fp+6rryN2VZI51G4nY46sJqu7pFgXIWajw0iIC6w1/YQBXcNB/K1uRLLZePXucsR9tlvCIP7wPtiPhQk",
+	"JLt0UfecAS/m+AMGDNVKMqu5NJxuLd1n7vAPAb1wrh+X7lIPGlXXMgQvL3lxNdOqluVh20eRS7MCHXDw",
+	"ePKYAPTtHpeqqsKjwVmqfXbUEsBrVnqT+57IhGfJFyGLkWjo7Dsh4MIKVdULaVL+peOPkcM2qftpPHGb",
+	"Kz1yJhXSaoakbztdp3y75oKL28ZU3k0Kbyq2sqo569YwSMuzSlJZBf7DTYzb+OKJQR4Bb6l1D7+ZTCaT",
+	"RG8XC2/sQWGuuzD2Ze1HEZ3j59zCjTLRgbfHk8cf5bCdu0xmsO2yPHM7gzD0iyqa+7E3NUmKjz5Gnec7",
+	"2zPLx+j0KaqRINJaeY/SsK6unOKYN1daJL36tmMPc610GvEutBfIOQt3KbClUhXlMYWxonDZmstaVCiB",
+	"ncGBrgS6BU4mKW322UtJjZ9DL1+gS3z8fjDxfY7UcofTY3izVAb8jY+uRVQbfYlvn0hJwPjixHvbZn6G",
+	"BGGx9U3Y7sObMuol7rVvJl99CEgScDQ3Tn6SNpO7LAdK5tja2wae00MPxl0iWHHzNHB2DrWLovJ6NWVK",
+	"Al2h7K3nYQg3NMnc7TBrc/HGbnhs7/BIqusW+FuUstxR1UVv7h0rV25fWfEAiqq+uV1R1X3HrAdtWRP7",
+	"LLzz4ALTy/qyEgWbR/Cl3ZcjvxHZnFP7ooUyLvvSdNdirg1U71QT2ahPmiZR95EmD6N/4Ax5Z9qhETUP",
+	"jz9BcX5U4rGFLm90hfnW4xa9+gATFQh42XQFsAwNifx91BuORcTs8zkfQzil4ygmgfo8XYCLFtUm3Ez+",
+	"+xg9Ubw/ROSHb52SPC5wN/vDVcc/IPE6+SJeb1N/PyZit0YUj0oszAsfhwyuV9Po7DkDOgS06Q/qJde/",
+	"Fy6nbgtllIHysIYX6at9FqyY9OVtvrJWGWgLAjXQyC5JRbe4tVdHYTUgZqgoJOnbIvoeyf4CE4wtRvEI",
+	"YRgR1TVP5lhr5KKm+Am32Chic+DtHf2CzWJgJz9hNKDmglSeBg8oUtW9ZDCxD1717hAMl+t9umGavh3s",
+	"Y/OSiWNPH4oQuw3qyqKQRLv5tG0JdhwNKujiW3//1OU6XFu7oSiJeuhvixlTQZ6mmZsSd38z0eh9bCPV",
+	"eeHat4fVKCdxrcDGGiFHp4dZENTAtqElBDlPzWLvSb+3439gB6o38VDHt0j6hL2oeBE96bHViaLIjtvF",
+	"oSK/uWWxuczMH2Gn/4QslVe1U7rZGz9lX0/+xtoOza+FfF0b+I6VQPvb55gW7l0u22OXxgIvN/hlXc78",
+	"7/DMujy5ySsbwczkv3T/JJyzLnwPxDE7bzLoiJq1a+Qw8NFCJaVkmL4+p2L3cOQKp3IbKd6sUgVD6bu4",
+	"FNn4H5sRmYQ3vmTLnSNoqkbniC0c5hJAUp0zWsL4rt10sPyB6Y7JF91xaxdxoD+wptffEbJR/IR37pEC",
+	"dBdKAgMBPqZb06yDggtaxgKsFsXoKn71r2xdBDlSy8pXSY96UV1YPRAhyf1CqwXYOdSG4ZAu2ylC7hRl",
+	"S38pMcmir8PiaKU6vtVltE/wag500Tm5VtJYKu7nRQFLa5jVfDoVxT77ySn1uahc8hbvbnXHjMy8tv7W",
+	"T1d5lk40emFExgMv5lBuSNa219HcIxu1kyTo40ILVrk1BhTcdaJ2FATE1CYwRpihGdPl1rOb3ss9OeB2",
+	"PxHSab9aV/4ilsODg0oVvJorYw//OvnrJLu5uPmfAQA9dM2yuJ4AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1,6 +1,6 @@
 // Package api provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
 package api
 
 import (
@@ -9,12 +9,49 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// Defines values for DayOffRecordDayOffType.
 const (
-	Bereavement   DayOffRecordDayOffType = "bereavement"
-	PTO           DayOffRecordDayOffType = "PTO"
-	ParentalLeave DayOffRecordDayOffType = "parental leave"
-	SickLeave     DayOffRecordDayOffType = "sick leave"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AuditEventEntityType.
+const (
+	AuditEventEntityTypeDayOff    AuditEventEntityType = "day_off"
+	AuditEventEntityTypeEmployee  AuditEventEntityType = "employee"
+	AuditEventEntityTypeHoliday   AuditEventEntityType = "holiday"
+	AuditEventEntityTypeLeaveType AuditEventEntityType = "leave_type"
+)
+
+// Defines values for AuditEventOperation.
+const (
+	Approve   AuditEventOperation = "approve"
+	Cancel    AuditEventOperation = "cancel"
+	Create    AuditEventOperation = "create"
+	Delete    AuditEventOperation = "delete"
+	Rehire    AuditEventOperation = "rehire"
+	Reject    AuditEventOperation = "reject"
+	Terminate AuditEventOperation = "terminate"
+	Update    AuditEventOperation = "update"
+)
+
+// Defines values for DayOffRecordHalfDay.
+const (
+	AM DayOffRecordHalfDay = "AM"
+	PM DayOffRecordHalfDay = "PM"
+)
+
+// Defines values for DayOffRecordStatus.
+const (
+	DayOffRecordStatusApproved  DayOffRecordStatus = "approved"
+	DayOffRecordStatusCancelled DayOffRecordStatus = "cancelled"
+	DayOffRecordStatusPending   DayOffRecordStatus = "pending"
+	DayOffRecordStatusRejected  DayOffRecordStatus = "rejected"
+	DayOffRecordStatusWithdrawn DayOffRecordStatus = "withdrawn"
+)
+
+// Defines values for DependencyCheckStatus.
+const (
+	DependencyCheckStatusDown DependencyCheckStatus = "down"
+	DependencyCheckStatusUp   DependencyCheckStatus = "up"
 )
 
 // Defines values for EmployeeDepartment.
@@ -26,6 +63,32 @@ const (
 	EmployeeDepartmentSales          EmployeeDepartment = "Sales"
 )
 
+// Defines values for EmployeeEmploymentStatus.
+const (
+	Active     EmployeeEmploymentStatus = "active"
+	Terminated EmployeeEmploymentStatus = "terminated"
+)
+
+// Defines values for EmployeeImportStatus.
+const (
+	Failed    EmployeeImportStatus = "failed"
+	Running   EmployeeImportStatus = "running"
+	Succeeded EmployeeImportStatus = "succeeded"
+)
+
+// Defines values for HealthStatus.
+const (
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusDown     HealthStatus = "down"
+	HealthStatusUp       HealthStatus = "up"
+)
+
+// Defines values for LeaveTypeEntitlement.
+const (
+	Flat      LeaveTypeEntitlement = "flat"
+	Seniority LeaveTypeEntitlement = "seniority"
+)
+
 // Defines values for NewEmployeeDepartment.
 const (
 	NewEmployeeDepartmentDesign         NewEmployeeDepartment = "Design"
@@ -35,6 +98,29 @@ const (
 	NewEmployeeDepartmentSales          NewEmployeeDepartment = "Sales"
 )
 
+// Defines values for ReadinessStatus.
+const (
+	NotReady ReadinessStatus = "not_ready"
+	Ready    ReadinessStatus = "ready"
+)
+
+// Defines values for ListAuditEventsParamsEntityType.
+const (
+	ListAuditEventsParamsEntityTypeDayOff    ListAuditEventsParamsEntityType = "day_off"
+	ListAuditEventsParamsEntityTypeEmployee  ListAuditEventsParamsEntityType = "employee"
+	ListAuditEventsParamsEntityTypeHoliday   ListAuditEventsParamsEntityType = "holiday"
+	ListAuditEventsParamsEntityTypeLeaveType ListAuditEventsParamsEntityType = "leave_type"
+)
+
+// Defines values for GetOrgChartParamsDepartment.
+const (
+	Design         GetOrgChartParamsDepartment = "Design"
+	Engineering    GetOrgChartParamsDepartment = "Engineering"
+	Financial      GetOrgChartParamsDepartment = "Financial"
+	GeneralAffairs GetOrgChartParamsDepartment = "General affairs"
+	Sales          GetOrgChartParamsDepartment = "Sales"
+)
+
 // Defines values for ListEmployeesParamsSortBy.
 const (
 	Department  ListEmployeesParamsSortBy = "department"
@@ -61,48 +147,318 @@ const (
 	ListDayOffsParamsSortOrderDesc ListDayOffsParamsSortOrder = "desc"
 )
 
+// Defines values for ListDayOffsParamsFiltersStatus.
+const (
+	ListDayOffsParamsFiltersStatusApproved  ListDayOffsParamsFiltersStatus = "approved"
+	ListDayOffsParamsFiltersStatusCancelled ListDayOffsParamsFiltersStatus = "cancelled"
+	ListDayOffsParamsFiltersStatusPending   ListDayOffsParamsFiltersStatus = "pending"
+	ListDayOffsParamsFiltersStatusRejected  ListDayOffsParamsFiltersStatus = "rejected"
+	ListDayOffsParamsFiltersStatusWithdrawn ListDayOffsParamsFiltersStatus = "withdrawn"
+)
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	// ActorID Missing for changes made by the system
+	ActorID   *int64  `json:"actorID,omitempty"`
+	ActorRole *string `json:"actorRole,omitempty"`
+
+	// Changes Changed fields by name
+	Changes    map[string]FieldChange `json:"changes"`
+	EntityID   int64                  `json:"entityID"`
+	EntityType AuditEventEntityType   `json:"entityType"`
+	Id         int64                  `json:"id"`
+	OccurredAt time.Time              `json:"occurredAt"`
+	Operation  AuditEventOperation    `json:"operation"`
+}
+
+// AuditEventEntityType defines model for AuditEvent.EntityType.
+type AuditEventEntityType string
+
+// AuditEventOperation defines model for AuditEvent.Operation.
+type AuditEventOperation string
+
+// DatabasePoolStats defines model for DatabasePoolStats.
+type DatabasePoolStats struct {
+	Idle               int   `json:"idle"`
+	InUse              int   `json:"inUse"`
+	MaxIdleClosed      int64 `json:"maxIdleClosed"`
+	MaxIdleTimeClosed  int64 `json:"maxIdleTimeClosed"`
+	MaxLifetimeClosed  int64 `json:"maxLifetimeClosed"`
+	MaxOpenConnections int   `json:"maxOpenConnections"`
+	OpenConnections    int   `json:"openConnections"`
+	WaitCount          int64 `json:"waitCount"`
+	WaitDurationMs     int64 `json:"waitDurationMs"`
+}
+
 // DayOffRecord defines model for DayOffRecord.
 type DayOffRecord struct {
-	DayOffType DayOffRecordDayOffType `json:"dayOffType"`
+	// AttachmentURL Link to a supporting document, required by some leave types
+	AttachmentURL      *string `json:"attachmentURL,omitempty"`
+	CancellationReason *string `json:"cancellationReason,omitempty"`
+
+	// CancelledAt When the request was withdrawn or cancelled
+	CancelledAt *time.Time `json:"cancelledAt,omitempty"`
+
+	// CancelledBy Id of the employee who withdrew or cancelled the request
+	CancelledBy *int64 `json:"cancelledBy,omitempty"`
+
+	// DayOffType Code of an active leave type, see /leave-types
+	DayOffType string `json:"dayOffType"`
+
+	// DurationDays Working days of the request, deducted from the leave balance
+	DurationDays *float64 `json:"durationDays,omitempty"`
+
+	// DurationHours Working hours of the request, by the work schedule; a half day is half of the daily hours
+	DurationHours *float64 `json:"durationHours,omitempty"`
 
 	// EmployeeID Unique id of the employee
 	EmployeeID int64     `json:"employeeID"`
 	EndTime    time.Time `json:"endTime"`
-	Reason     string    `json:"reason"`
-	StartTime  time.Time `json:"startTime"`
+
+	// HalfDay Requests the morning or the afternoon of the working day of startTime. The start and end times are then set to the working hours of that half day.
+	HalfDay       *DayOffRecordHalfDay `json:"halfDay,omitempty"`
+	Id            *int64               `json:"id,omitempty"`
+	Reason        string               `json:"reason"`
+	ReviewComment *string              `json:"reviewComment,omitempty"`
+	ReviewedAt    *time.Time           `json:"reviewedAt,omitempty"`
+
+	// ReviewerID Id of the employee who approved or rejected the request
+	ReviewerID  *int64              `json:"reviewerID,omitempty"`
+	StartTime   time.Time           `json:"startTime"`
+	Status      *DayOffRecordStatus `json:"status,omitempty"`
+	SubmittedAt *time.Time          `json:"submittedAt,omitempty"`
+}
+
+// DayOffRecordHalfDay Requests the morning or the afternoon of the working day of startTime. The start and end times are then set to the working hours of that half day.
+type DayOffRecordHalfDay string
+
+// DayOffRecordStatus defines model for DayOffRecord.Status.
+type DayOffRecordStatus string
+
+// DayOffReview The reviewer is the authenticated caller
+type DayOffReview struct {
+	// Comment Required when rejecting a request
+	Comment *string `json:"comment,omitempty"`
+}
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	// Error why the dependency is down, only reported by getHealth
+	Error     *string `json:"error,omitempty"`
+	LatencyMs float64 `json:"latencyMs"`
+
+	// Required whether the instance is ready only if the dependency is up
+	Required bool                  `json:"required"`
+	Status   DependencyCheckStatus `json:"status"`
 }
 
-// DayOffRecordDayOffType defines model for DayOffRecord.DayOffType.
-type DayOffRecordDayOffType string
+// DependencyCheckStatus defines model for DependencyCheck.Status.
+type DependencyCheckStatus string
 
-// Employee defines model for Employee.
+// Employee An employee as seen by the caller. Salary, address and phone number are omitted unless the caller is the employee, their direct manager (salary and phone number only) or an HR admin.
 type Employee struct {
-	Address    string              `json:"address"`
-	Department EmployeeDepartment  `json:"department"`
-	Email      openapi_types.Email `json:"email"`
+	Address          *string                   `json:"address,omitempty"`
+	Department       EmployeeDepartment        `json:"department"`
+	Email            openapi_types.Email       `json:"email"`
+	EmploymentStatus *EmployeeEmploymentStatus `json:"employmentStatus,omitempty"`
 
 	// Id Unique id of the employee
 	Id    int64  `json:"id"`
 	Level string `json:"level"`
 
+	// ManagerID Id of the employee this employee reports to
+	ManagerID *int64 `json:"managerID"`
+
 	// Name Name of the employee
 	Name        string             `json:"name"`
 	OnboardDate openapi_types.Date `json:"onboardDate"`
-	PhoneNumber string             `json:"phoneNumber"`
-	Salary      int                `json:"salary"`
-	Title       string             `json:"title"`
+	PhoneNumber *string            `json:"phoneNumber,omitempty"`
+
+	// Region Where the employee works, the holidays of the region are not counted as leave
+	Region *Region `json:"region,omitempty"`
+
+	// Salary Monthly salary in NTD
+	Salary          *int                `json:"salary,omitempty"`
+	TerminationDate *openapi_types.Date `json:"terminationDate,omitempty"`
+
+	// TerminationReason Only visible to the employee and HR admins
+	TerminationReason *string `json:"terminationReason,omitempty"`
+	Title             string  `json:"title"`
 }
 
 // EmployeeDepartment defines model for Employee.Department.
 type EmployeeDepartment string
 
+// EmployeeEmploymentStatus defines model for Employee.EmploymentStatus.
+type EmployeeEmploymentStatus string
+
+// EmployeeImport defines model for EmployeeImport.
+type EmployeeImport struct {
+	CreatedAt time.Time `json:"createdAt"`
+	DryRun    bool      `json:"dryRun"`
+
+	// Error Why a failed import stopped, rows after the processed ones were not imported
+	Error *string `json:"error,omitempty"`
+
+	// Errors Errors of the failed rows, only the first 1000 are kept
+	Errors     []ImportRowError `json:"errors"`
+	FailedRows int              `json:"failedRows"`
+	FinishedAt *time.Time       `json:"finishedAt,omitempty"`
+	Id         int64            `json:"id"`
+
+	// ImportedRows Rows created, for dry runs the rows that would have been created
+	ImportedRows int `json:"importedRows"`
+
+	// ProcessedRows Rows validated, and unless it is a dry run written, so far
+	ProcessedRows int                  `json:"processedRows"`
+	Status        EmployeeImportStatus `json:"status"`
+	TotalRows     int                  `json:"totalRows"`
+}
+
+// EmployeeImportStatus defines model for EmployeeImport.Status.
+type EmployeeImportStatus string
+
 // Error defines model for Error.
 type Error struct {
-	// Code Error code
-	Code int `json:"code"`
+	// Code Stable machine-readable error code, e.g. employee_not_found
+	Code string `json:"code"`
 
-	// Message Error message
+	// Details Field level details for validation errors
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Message Human readable error message
 	Message string `json:"message"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+}
+
+// ErrorDetail defines model for ErrorDetail.
+type ErrorDetail struct {
+	Code string `json:"code"`
+
+	// Field Name of the request field the error refers to
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldChange defines model for FieldChange.
+type FieldChange struct {
+	// After Value after the mutation, null for deletions
+	After interface{} `json:"after,omitempty"`
+
+	// Before Value before the mutation, null for creations
+	Before interface{} `json:"before,omitempty"`
+}
+
+// Health defines model for Health.
+type Health struct {
+	Checks       map[string]DependencyCheck `json:"checks"`
+	Commit       string                     `json:"commit"`
+	DatabasePool DatabasePoolStats          `json:"databasePool"`
+	StartTime    string                     `json:"startTime"`
+
+	// Status degraded when only optional dependencies are down
+	Status  HealthStatus `json:"status"`
+	Version string       `json:"version"`
+}
+
+// HealthStatus degraded when only optional dependencies are down
+type HealthStatus string
+
+// Holiday defines model for Holiday.
+type Holiday struct {
+	Date openapi_types.Date `json:"date"`
+	Id   *int64             `json:"id,omitempty"`
+	Name string             `json:"name"`
+
+	// Region ISO 3166 code of a country or subdivision, e.g. TW or US-CA
+	Region Region `json:"region"`
+}
+
+// HolidayImport Counts the days of the calendar by what the import did with them
+type HolidayImport struct {
+	Created   int `json:"created"`
+	Unchanged int `json:"unchanged"`
+
+	// Updated Days the region already had a holiday on, under another name
+	Updated int `json:"updated"`
+}
+
+// ImportRowError defines model for ImportRowError.
+type ImportRowError struct {
+	Code string `json:"code"`
+
+	// Field Name of the NewEmployee property the error refers to
+	Field   *string `json:"field,omitempty"`
+	Message string  `json:"message"`
+
+	// Row Number of the row, starting at 1 and not counting the CSV header
+	Row int `json:"row"`
+}
+
+// LeaveBalance defines model for LeaveBalance.
+type LeaveBalance struct {
+	DayOffType string `json:"dayOffType"`
+
+	// EntitledDays Days granted for the year
+	EntitledDays  float64 `json:"entitledDays"`
+	RemainingDays float64 `json:"remainingDays"`
+
+	// UsedDays Days taken by pending and approved requests
+	UsedDays float64 `json:"usedDays"`
+}
+
+// LeaveType defines model for LeaveType.
+type LeaveType struct {
+	// Active Inactive types are kept for their day offs but can no longer be requested
+	Active *bool `json:"active,omitempty"`
+
+	// AnnualDays Days granted per year by a flat entitlement
+	AnnualDays *float64 `json:"annualDays,omitempty"`
+
+	// Code What day off records use as their dayOffType
+	Code string `json:"code"`
+
+	// Entitlement How the yearly days are granted. seniority grows with the years of service and the level, like PTO; flat grants annualDays, pro-rated in the onboarding year.
+	Entitlement LeaveTypeEntitlement `json:"entitlement"`
+	Id          *int64               `json:"id,omitempty"`
+
+	// MaxConsecutiveDays Most working days of a single request, 0 for no limit
+	MaxConsecutiveDays *int `json:"maxConsecutiveDays,omitempty"`
+
+	// MinNoticeDays Days between the request and the first day off, 0 to allow requests for today
+	MinNoticeDays *int `json:"minNoticeDays,omitempty"`
+
+	// MinServiceMonths Months of service before the type can be requested, e.g. the probation period
+	MinServiceMonths *int   `json:"minServiceMonths,omitempty"`
+	Name             string `json:"name"`
+
+	// Paid Unpaid leave is not counted against a balance, so it can be requested without one
+	Paid bool `json:"paid"`
+
+	// RequiresApproval Requests that do not need approval are approved when they are submitted
+	RequiresApproval bool `json:"requiresApproval"`
+
+	// RequiresAttachment Requests must link a supporting document in attachmentURL
+	RequiresAttachment bool `json:"requiresAttachment"`
+}
+
+// LeaveTypeEntitlement How the yearly days are granted. seniority grows with the years of service and the level, like PTO; flat grants annualDays, pro-rated in the onboarding year.
+type LeaveTypeEntitlement string
+
+// ListAuditEventsResponse defines model for ListAuditEventsResponse.
+type ListAuditEventsResponse struct {
+	Data []AuditEvent `json:"data"`
+
+	// Page Current page number
+	Page int `json:"page"`
+
+	// PageSize Number of items per page
+	PageSize int `json:"pageSize"`
+
+	// TotalCount Total number of records
+	TotalCount int64 `json:"totalCount"`
 }
 
 // ListDayOffsResponse defines model for ListDayOffsResponse.
@@ -133,6 +489,32 @@ type ListEmployeesResponse struct {
 	TotalCount int64 `json:"totalCount"`
 }
 
+// ListHolidaysResponse defines model for ListHolidaysResponse.
+type ListHolidaysResponse struct {
+	Data []Holiday `json:"data"`
+
+	// Page Current page number
+	Page int `json:"page"`
+
+	// PageSize Number of items per page
+	PageSize int `json:"pageSize"`
+
+	// TotalCount Total number of holidays
+	TotalCount int64 `json:"totalCount"`
+}
+
+// ListLeaveBalancesResponse defines model for ListLeaveBalancesResponse.
+type ListLeaveBalancesResponse struct {
+	Data       []LeaveBalance `json:"data"`
+	EmployeeID int64          `json:"employeeID"`
+	Year       int            `json:"year"`
+}
+
+// ListLeaveTypesResponse defines model for ListLeaveTypesResponse.
+type ListLeaveTypesResponse struct {
+	Data []LeaveType `json:"data"`
+}
+
 // NewEmployee defines model for NewEmployee.
 type NewEmployee struct {
 	Address    string                `json:"address"`
@@ -140,22 +522,78 @@ type NewEmployee struct {
 	Email      openapi_types.Email   `json:"email"`
 	Level      string                `json:"level"`
 
+	// ManagerID Id of the employee this employee reports to
+	ManagerID *int64 `json:"managerID"`
+
 	// Name Name of the employee
 	Name        string             `json:"name"`
 	OnboardDate openapi_types.Date `json:"onboardDate"`
 	PhoneNumber string             `json:"phoneNumber"`
-	Salary      int                `json:"salary"`
-	Title       string             `json:"title"`
+
+	// Region Where the employee works, the holidays of the region are not counted as leave
+	Region *Region `json:"region,omitempty"`
+
+	// Salary Monthly salary in NTD
+	Salary int    `json:"salary"`
+	Title  string `json:"title"`
 }
 
 // NewEmployeeDepartment defines model for NewEmployee.Department.
 type NewEmployeeDepartment string
 
+// OrgChartNode defines model for OrgChartNode.
+type OrgChartNode struct {
+	// Employee An employee as seen by the caller. Salary, address and phone number are omitted unless the caller is the employee, their direct manager (salary and phone number only) or an HR admin.
+	Employee Employee       `json:"employee"`
+	Reports  []OrgChartNode `json:"reports"`
+}
+
 // Pong defines model for Pong.
 type Pong struct {
 	StartTime string `json:"startTime"`
 }
 
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Checks map[string]DependencyCheck `json:"checks"`
+	Status ReadinessStatus            `json:"status"`
+}
+
+// ReadinessStatus defines model for Readiness.Status.
+type ReadinessStatus string
+
+// Region ISO 3166 code of a country or subdivision, e.g. TW or US-CA
+type Region = string
+
+// ListAuditEventsParams defines parameters for ListAuditEvents.
+type ListAuditEventsParams struct {
+	Page       *int                             `form:"page,omitempty" json:"page,omitempty"`
+	PageSize   *int                             `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+	EntityType *ListAuditEventsParamsEntityType `form:"entityType,omitempty" json:"entityType,omitempty"`
+	EntityID   *int64                           `form:"entityID,omitempty" json:"entityID,omitempty"`
+
+	// ActorID ID of the employee who performed the mutation
+	ActorID *int64 `form:"actorID,omitempty" json:"actorID,omitempty"`
+
+	// From Only events at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only events before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListAuditEventsParamsEntityType defines parameters for ListAuditEvents.
+type ListAuditEventsParamsEntityType string
+
+// GetOrgChartParams defines parameters for GetOrgChart.
+type GetOrgChartParams struct {
+	// IncludeTerminated Also chart employees who left the company
+	IncludeTerminated *bool `form:"includeTerminated,omitempty" json:"includeTerminated,omitempty"`
+}
+
+// GetOrgChartParamsDepartment defines parameters for GetOrgChart.
+type GetOrgChartParamsDepartment string
+
 // ListEmployeesParams defines parameters for ListEmployees.
 type ListEmployeesParams struct {
 	Page      *int                          `form:"page,omitempty" json:"page,omitempty"`
@@ -165,6 +603,9 @@ type ListEmployeesParams struct {
 
 	// Filters Key-value pairs for filtering records (e.g., filters[department]=Engineering&filters[name]=John)
 	Filters *map[string]string `json:"filters,omitempty"`
+
+	// IncludeTerminated Also list employees who left the company
+	IncludeTerminated *bool `form:"includeTerminated,omitempty" json:"includeTerminated,omitempty"`
 }
 
 // ListEmployeesParamsSortBy defines parameters for ListEmployees.
@@ -180,15 +621,29 @@ type CancelDayOffJSONBody struct {
 
 // ListDayOffsParams defines parameters for ListDayOffs.
 type ListDayOffsParams struct {
-	Page          *int                        `form:"page,omitempty" json:"page,omitempty"`
-	PageSize      *int                        `form:"pageSize,omitempty" json:"pageSize,omitempty"`
-	SortBy        *ListDayOffsParamsSortBy    `form:"sortBy,omitempty" json:"sortBy,omitempty"`
-	SortOrder     *ListDayOffsParamsSortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
-	StartTimeFrom *openapi_types.Date         `form:"startTimeFrom,omitempty" json:"startTimeFrom,omitempty"`
-	StartTimeTo   *openapi_types.Date         `form:"startTimeTo,omitempty" json:"startTimeTo,omitempty"`
-
-	// Filters Key-value pairs for filtering records (e.g., filters[dayOffType]=PTO)
-	Filters *map[string]string `json:"filters,omitempty"`
+	Page      *int                        `form:"page,omitempty" json:"page,omitempty"`
+	PageSize  *int                        `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+	SortBy    *ListDayOffsParamsSortBy    `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortOrder *ListDayOffsParamsSortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+
+	// StartTimeFrom Only records starting on or after this date
+	StartTimeFrom *openapi_types.Date `form:"startTimeFrom,omitempty" json:"startTimeFrom,omitempty"`
+
+	// StartTimeTo Only records starting on or before this date
+	StartTimeTo *openapi_types.Date `form:"startTimeTo,omitempty" json:"startTimeTo,omitempty"`
+
+	// From Only records overlapping the range from this date, e.g. leave that started earlier and is still running
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only records overlapping the range up to and including this date
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// Filters Exact matches on the type and status of the records (e.g., filters[dayOffType]=PTO&filters[status]=approved)
+	Filters *struct {
+		// DayOffType Code of a leave type
+		DayOffType *string                         `json:"dayOffType,omitempty"`
+		Status     *ListDayOffsParamsFiltersStatus `json:"status,omitempty"`
+	} `json:"filters,omitempty"`
 }
 
 // ListDayOffsParamsSortBy defines parameters for ListDayOffs.
@@ -197,14 +652,103 @@ type ListDayOffsParamsSortBy string
 // ListDayOffsParamsSortOrder defines parameters for ListDayOffs.
 type ListDayOffsParamsSortOrder string
 
+// ListDayOffsParamsFiltersStatus defines parameters for ListDayOffs.
+type ListDayOffsParamsFiltersStatus string
+
+// ListDirectReportsParams defines parameters for ListDirectReports.
+type ListDirectReportsParams struct {
+	// IncludeTerminated Also list employees who left the company
+	IncludeTerminated *bool `form:"includeTerminated,omitempty" json:"includeTerminated,omitempty"`
+}
+
+// ListLeaveBalancesParams defines parameters for ListLeaveBalances.
+type ListLeaveBalancesParams struct {
+	// Year Balance year, defaults to the current year
+	Year *int `form:"year,omitempty" json:"year,omitempty"`
+}
+
+// RehireEmployeeJSONBody defines parameters for RehireEmployee.
+type RehireEmployeeJSONBody struct {
+	// OnboardDate First day of the new employment, after the termination date. Seniority restarts from it, the leave accrued for the current year is kept.
+	OnboardDate openapi_types.Date `json:"onboardDate"`
+}
+
+// TerminateEmployeeJSONBody defines parameters for TerminateEmployee.
+type TerminateEmployeeJSONBody struct {
+	Reason *string `json:"reason,omitempty"`
+
+	// TerminationDate Last day of employment, today at the latest. Day offs ending after it are withdrawn or cancelled.
+	TerminationDate openapi_types.Date `json:"terminationDate"`
+}
+
+// ImportEmployeesJSONBody defines parameters for ImportEmployees.
+type ImportEmployeesJSONBody = []map[string]interface{}
+
+// ImportEmployeesParams defines parameters for ImportEmployees.
+type ImportEmployeesParams struct {
+	// DryRun Only validate the rows, nothing is created
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// ListHolidaysParams defines parameters for ListHolidays.
+type ListHolidaysParams struct {
+	Region *Region `form:"region,omitempty" json:"region,omitempty"`
+
+	// From Only holidays on or after this date
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only holidays on or before this date
+	To       *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+	Page     *int                `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *int                `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
+// ImportHolidaysParams defines parameters for ImportHolidays.
+type ImportHolidaysParams struct {
+	Region Region `form:"region" json:"region"`
+}
+
+// ListLeaveTypesParams defines parameters for ListLeaveTypes.
+type ListLeaveTypesParams struct {
+	// IncludeInactive Also return the types that can no longer be requested
+	IncludeInactive *bool `form:"includeInactive,omitempty" json:"includeInactive,omitempty"`
+}
+
 // AddEmployeeJSONRequestBody defines body for AddEmployee for application/json ContentType.
 type AddEmployeeJSONRequestBody = NewEmployee
 
+// ApproveDayOffJSONRequestBody defines body for ApproveDayOff for application/json ContentType.
+type ApproveDayOffJSONRequestBody = DayOffReview
+
 // CancelDayOffJSONRequestBody defines body for CancelDayOff for application/json ContentType.
 type CancelDayOffJSONRequestBody CancelDayOffJSONBody
 
+// RejectDayOffJSONRequestBody defines body for RejectDayOff for application/json ContentType.
+type RejectDayOffJSONRequestBody = DayOffReview
+
 // UpdateEmployeeJSONRequestBody defines body for UpdateEmployee for application/json ContentType.
 type UpdateEmployeeJSONRequestBody = NewEmployee
 
 // SubmitDayOffJSONRequestBody defines body for SubmitDayOff for application/json ContentType.
 type SubmitDayOffJSONRequestBody = DayOffRecord
+
+// RehireEmployeeJSONRequestBody defines body for RehireEmployee for application/json ContentType.
+type RehireEmployeeJSONRequestBody RehireEmployeeJSONBody
+
+// TerminateEmployeeJSONRequestBody defines body for TerminateEmployee for application/json ContentType.
+type TerminateEmployeeJSONRequestBody TerminateEmployeeJSONBody
+
+// ImportEmployeesJSONRequestBody defines body for ImportEmployees for application/json ContentType.
+type ImportEmployeesJSONRequestBody = ImportEmployeesJSONBody
+
+// CreateHolidayJSONRequestBody defines body for CreateHoliday for application/json ContentType.
+type CreateHolidayJSONRequestBody = Holiday
+
+// UpdateHolidayJSONRequestBody defines body for UpdateHoliday for application/json ContentType.
+type UpdateHolidayJSONRequestBody = Holiday
+
+// CreateLeaveTypeJSONRequestBody defines body for CreateLeaveType for application/json ContentType.
+type CreateLeaveTypeJSONRequestBody = LeaveType
+
+// UpdateLeaveTypeJSONRequestBody defines body for UpdateLeaveType for application/json ContentType.
+type UpdateLeaveTypeJSONRequestBody = LeaveType
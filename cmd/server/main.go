@@ -1,76 +1,320 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	middleware "github.com/oapi-codegen/gin-middleware"
+	"gorm.io/gorm"
 
 	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/auth"
+	"github.com/joremysh/fliqt/internal/config"
 	"github.com/joremysh/fliqt/internal/handler"
-	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/internal/logging"
+	"github.com/joremysh/fliqt/internal/metrics"
+	"github.com/joremysh/fliqt/internal/tracing"
+	"github.com/joremysh/fliqt/internal/worktime"
 	"github.com/joremysh/fliqt/pkg/cache"
 	"github.com/joremysh/fliqt/pkg/database"
 )
 
-func NewServer(hrSystem *handler.HRSystem, port string) *http.Server {
+func NewServer(hrSystem *handler.HRSystem, verifier *auth.Verifier, cfg *config.Config, loggers *logging.Loggers) *http.Server {
 	swagger, err := api.GetSwagger()
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading swagger spec\n: %s", err)
-		os.Exit(1)
+		fatal(loggers.For(logging.ComponentApp), "failed to load the swagger spec", err)
 	}
 
 	// Clear out the servers array in the swagger spec, that skips validating
 	// that server names match. We don't know how this thing will be run.
 	swagger.Servers = nil
-	r := gin.Default()
+	if cfg.Log.Level != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	httpLogger := loggers.For(logging.ComponentHTTP)
+	r := gin.New()
+	r.Use(otelgin.Middleware(cfg.Tracing.ServiceName))
+	r.Use(logging.NewRequestIDMiddleware())
+	if cfg.Features.AccessLog {
+		r.Use(logging.NewAccessLogMiddleware(httpLogger))
+	}
+	r.Use(logging.NewRecoveryMiddleware(httpLogger))
+	r.Use(metrics.NewHTTPMiddleware(swagger))
+
+	// Holiday calendars are uploaded as iCalendar files, which the validator
+	// only checks to be a string.
+	openapi3filter.RegisterBodyDecoder("text/calendar", openapi3filter.FileBodyDecoder)
 
 	// Use our validation middleware to check all requests against the
-	// OpenAPI schema.
-	r.Use(middleware.OapiRequestValidator(swagger))
+	// OpenAPI schema, including the bearer token of secured operations.
+	r.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+		ErrorHandler: handler.ValidationErrorHandler,
+		Options: openapi3filter.Options{
+			AuthenticationFunc: auth.NewAuthenticationFunc(verifier),
+		},
+	}))
+
+	r.Use(tracing.NewOperationMiddleware(swagger))
 
 	api.RegisterHandlers(r, hrSystem)
 
 	s := &http.Server{
-		Handler: r,
-		Addr:    net.JoinHostPort("0.0.0.0", port),
+		Handler:           r,
+		Addr:              net.JoinHostPort("0.0.0.0", strconv.Itoa(cfg.Server.Port)),
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
 	}
 	return s
 }
 
 func main() {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err == nil {
+		err = cfg.Log.Validate()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
-	dsn := os.Getenv("DSN")
-	redisHost := os.Getenv("REDIS_HOST")
-	redisPort := os.Getenv("REDIS_PORT")
 
-	gdb, err := database.NewDatabase(dsn)
+	loggers, err := logging.New(logging.Options{
+		Output: os.Stdout,
+		Level:  cfg.Log.Level,
+		Levels: cfg.Log.Levels,
+	})
 	if err != nil {
-		log.Fatal(err.Error())
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	logger := loggers.For(logging.ComponentApp)
+	// anything still written through the log package or the default slog logger ends up as JSON too
+	slog.SetDefault(logger)
+	redis.SetLogger(logging.RedisLogger{Logger: loggers.For(logging.ComponentCache)})
+
+	switch {
+	case len(args) == 0:
+		runServer(cfg, loggers)
+	case args[0] == "migrate":
+		err = runMigrate(cfg, loggers, args[1:])
+	case args[0] == "seed":
+		err = runSeed(cfg, loggers, args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q, expected none, migrate or seed\n", args[0])
+		os.Exit(2)
 	}
-	err = repository.Migrate(gdb)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// runServer serves the API until SIGINT or SIGTERM. The schema has to be migrated beforehand.
+func runServer(cfg *config.Config, loggers *logging.Loggers) {
+	logger := loggers.For(logging.ComponentApp)
+	if err := cfg.Validate(); err != nil {
+		fatal(logger, "invalid configuration", err)
+	}
+	schedule, err := newSchedule(cfg.WorkSchedule)
+	if err != nil {
+		fatal(logger, "invalid configuration", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName:    cfg.Tracing.ServiceName,
+		ServiceVersion: handler.Version,
+		Exporter:       cfg.Tracing.Exporter,
+		OTLPEndpoint:   cfg.Tracing.OTLPEndpoint,
+		File:           cfg.Tracing.File,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+	})
 	if err != nil {
-		log.Fatal(err.Error())
+		fatal(logger, "failed to set up tracing", err)
 	}
 
-	redisClient, err := cache.NewRedisClient(net.JoinHostPort(redisHost, redisPort))
+	gdb, err := openDatabase(cfg, loggers)
 	if err != nil {
-		log.Fatal(err.Error())
+		fatal(logger, "failed to connect to the database", err)
+	}
+
+	employeeCache, err := cache.New(cache.Options{
+		Backend: cfg.Cache.Backend,
+		Redis: cache.RedisOptions{
+			Addr:          net.JoinHostPort(cfg.Redis.Host, strconv.Itoa(cfg.Redis.Port)),
+			Username:      cfg.Redis.Username,
+			Password:      cfg.Redis.Password,
+			DB:            cfg.Redis.DB,
+			TLS:           cfg.Redis.TLS,
+			TLSServerName: cfg.Redis.TLSServerName,
+			TLSCAFile:     cfg.Redis.TLSCAFile,
+		},
+		MemorySize:   cfg.Cache.MemorySize,
+		MemoryMaxTTL: cfg.Cache.MemoryMaxTTL,
+	})
+	if err != nil {
+		fatal(logger, "failed to set up the cache", err)
+	}
+	if redisClient, ok := employeeCache.(*cache.RedisClient); ok {
+		if err = redisClient.Ping(context.Background()); err != nil {
+			logger.Warn("redis is unreachable, serving without cache until it is back", "error", err)
+		}
+	}
+
+	verifier, err := newVerifier(cfg.JWT)
+	if err != nil {
+		fatal(logger, "failed to load the JWT keys", err)
 	}
 
 	handler.StartUp = time.Now().Format(time.RFC3339)
-	hrSystem := handler.NewHRSystem(gdb, redisClient)
-	s := NewServer(hrSystem, port)
+	hrSystem := handler.NewHRSystem(gdb, employeeCache, schedule)
+	s := NewServer(hrSystem, verifier, cfg, loggers)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	logger.Info("listening", "addr", s.Addr, "version", handler.Version, "commit", handler.Commit)
+	if err = serve(ctx, s, hrSystem, cfg.Server, logger); err != nil {
+		logger.Error("server stopped", "error", err)
+	}
 
-	log.Fatal(s.ListenAndServe())
+	if db, err := gdb.DB(); err == nil {
+		if err = db.Close(); err != nil {
+			logger.Error("failed to close the database", "error", err)
+		}
+	}
+	if closer, ok := employeeCache.(io.Closer); ok {
+		if err = closer.Close(); err != nil {
+			logger.Error("failed to close the cache", "error", err)
+		}
+	}
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = shutdownTracing(flushCtx); err != nil {
+		logger.Error("failed to flush traces", "error", err)
+	}
+}
+
+// serve runs the server until ctx is done. It then fails the readiness probe, waits for the
+// shutdown delay so that no new traffic is routed here, drains in-flight requests
+// and stops the background imports.
+func serve(ctx context.Context, s *http.Server, hrSystem *handler.HRSystem, cfg config.Server, logger *slog.Logger) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.ListenAndServe()
+	}()
+	hrSystem.SetReady(true)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down", "drain", (cfg.ShutdownDelay + cfg.ShutdownTimeout).String())
+	hrSystem.SetReady(false)
+	time.Sleep(cfg.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	err := s.Shutdown(shutdownCtx)
+	if importErr := hrSystem.Shutdown(shutdownCtx); importErr != nil {
+		logger.Warn("background imports did not stop in time", "error", importErr)
+	}
+	return err
+}
+
+func openDatabase(cfg *config.Config, loggers *logging.Loggers) (*gorm.DB, error) {
+	return database.NewDatabase(database.Options{
+		DSN:             cfg.Database.DSN,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+		Logger:          logging.NewGormLogger(loggers.For(logging.ComponentSQL), cfg.Log.SlowQuery, cfg.Log.RedactSQL),
+		QueryTimeout:    cfg.Database.QueryTimeout,
+		QueryTimeouts:   cfg.Database.QueryTimeouts,
+	})
+}
+
+// fatal logs err and exits, like log.Fatal.
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// newVerifier loads the JWT verification keys. HS256 tokens are accepted when a shared
+// secret is configured, RS256 tokens when a public key file is configured.
+func newVerifier(cfg config.JWT) (*auth.Verifier, error) {
+	authConfig := auth.Config{
+		HMACSecret: []byte(cfg.HMACSecret),
+		Issuer:     cfg.Issuer,
+		Audience:   cfg.Audience,
+	}
+	if cfg.HMACSecretFile != "" {
+		secret, err := auth.LoadHMACSecret(cfg.HMACSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		authConfig.HMACSecret = secret
+	}
+	if cfg.RSAPublicKeyFile != "" {
+		key, err := auth.LoadRSAPublicKey(cfg.RSAPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		authConfig.RSAPublicKey = key
+	}
+	return auth.NewVerifier(authConfig)
+}
+
+// newSchedule parses the work schedule leave is measured in.
+func newSchedule(cfg config.WorkSchedule) (worktime.Schedule, error) {
+	var errs []error
+	clock := func(name, value string) time.Duration {
+		d, err := worktime.ParseClock(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("workSchedule.%s: %w", name, err))
+		}
+		return d
+	}
+	schedule := worktime.Schedule{
+		DayStart:   clock("dayStart", cfg.DayStart),
+		DayEnd:     clock("dayEnd", cfg.DayEnd),
+		LunchStart: clock("lunchStart", cfg.LunchStart),
+		LunchEnd:   clock("lunchEnd", cfg.LunchEnd),
+	}
+	var err error
+	if schedule.WorkingDays, err = worktime.ParseWeekdays(cfg.WorkingDays); err != nil {
+		errs = append(errs, fmt.Errorf("workSchedule.workingDays: %w", err))
+	}
+	if schedule.Location, err = time.LoadLocation(cfg.TimeZone); err != nil {
+		errs = append(errs, fmt.Errorf("workSchedule.timeZone: %w", err))
+	}
+	if len(errs) > 0 {
+		return worktime.Schedule{}, errors.Join(errs...)
+	}
+	if err = schedule.Validate(); err != nil {
+		return worktime.Schedule{}, fmt.Errorf("workSchedule: %w", err)
+	}
+	return schedule, nil
 }
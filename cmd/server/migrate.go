@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joremysh/fliqt/internal/config"
+	"github.com/joremysh/fliqt/internal/logging"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/pkg/migrate"
+)
+
+const migrateUsage = `usage: server [flags] migrate <command>
+
+commands:
+  up             apply every pending migration
+  down [n]       revert the last n migrations, 1 by default
+  to <version>   migrate up or down to version, 0 reverts everything
+  status         list the migrations and whether they are applied
+  force <version>
+                 mark version as applied without running SQL, after repairing a failed migration`
+
+// runMigrate runs the migrate subcommand. Concurrent runs, e.g. from several replicas
+// starting at once, wait for each other on a database lock.
+func runMigrate(cfg *config.Config, loggers *logging.Loggers, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+	if err := cfg.Database.Validate(); err != nil {
+		return err
+	}
+
+	gdb, err := openDatabase(cfg, loggers)
+	if err != nil {
+		return err
+	}
+	if db, err := gdb.DB(); err == nil {
+		defer db.Close()
+	}
+	logger := loggers.For(logging.ComponentApp)
+	migrator, err := repository.NewMigrator(gdb, migrate.Options{
+		LockTimeout: 5 * time.Minute,
+		Logf: func(format string, args ...any) {
+			logger.Info(fmt.Sprintf(format, args...))
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	command, args := args[0], args[1:]
+	switch {
+	case command == "up" && len(args) == 0:
+		return migrator.Up(ctx)
+	case command == "down" && len(args) <= 1:
+		steps := 1
+		if len(args) == 1 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations %q", args[0])
+			}
+		}
+		return migrator.Down(ctx, steps)
+	case (command == "to" || command == "force") && len(args) == 1:
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		if command == "force" {
+			return migrator.Force(ctx, version)
+		}
+		return migrator.To(ctx, version)
+	case command == "status" && len(args) == 0:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	default:
+		return fmt.Errorf("invalid migrate command %q\n%s", command, migrateUsage)
+	}
+}
+
+func printStatus(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		switch {
+		case status.Dirty:
+			state = "dirty"
+		case status.Unknown:
+			state = "unknown"
+		case status.Applied:
+			state = "applied"
+		}
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
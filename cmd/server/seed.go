@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joremysh/fliqt/internal/config"
+	"github.com/joremysh/fliqt/internal/logging"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/internal/seed"
+)
+
+// runSeed runs the seed subcommand, which fills a freshly migrated, empty database.
+func runSeed(cfg *config.Config, loggers *logging.Loggers, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	profileName := flags.String("profile", "small-team", "dataset profile: "+strings.Join(seed.ProfileNames(), ", "))
+	size := flags.Int("size", 0, "number of employees, overrides the profile")
+	rngSeed := flags.Uint64("seed", 1, "random seed; the same seed, size, profile and date generate the same data, 0 picks one")
+	date := flags.String("date", "", "reference date the history is generated around, YYYY-MM-DD, today by default")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: server [flags] seed [seed flags]\n\nprofiles:\n")
+		for _, name := range seed.ProfileNames() {
+			profile, _ := seed.LookupProfile(name)
+			fmt.Fprintf(flags.Output(), "  %-14s %s\n", name, profile.Description)
+		}
+		fmt.Fprintf(flags.Output(), "\nseed flags:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+
+	profile, ok := seed.LookupProfile(*profileName)
+	if !ok {
+		return fmt.Errorf("unknown profile %q, expected one of %s", *profileName, strings.Join(seed.ProfileNames(), ", "))
+	}
+	if *size < 0 {
+		return fmt.Errorf("invalid size %d", *size)
+	}
+	var now time.Time
+	if *date != "" {
+		d, err := time.Parse(time.DateOnly, *date)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", *date, err)
+		}
+		now = d.Add(12 * time.Hour)
+	}
+	if *rngSeed == 0 {
+		*rngSeed = rand.Uint64()
+	}
+	if err := cfg.Database.Validate(); err != nil {
+		return err
+	}
+	schedule, err := newSchedule(cfg.WorkSchedule)
+	if err != nil {
+		return err
+	}
+
+	gdb, err := openDatabase(cfg, loggers)
+	if err != nil {
+		return err
+	}
+	if db, err := gdb.DB(); err == nil {
+		defer db.Close()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	// requests are generated for the leave types the database offers
+	leaveTypes, err := repository.NewLeaveTypeRepo(gdb).List(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	logger := loggers.For(logging.ComponentApp)
+	dataset, err := seed.Generate(seed.Options{
+		Profile: profile, Employees: *size, Seed: *rngSeed, Now: now, Schedule: schedule, LeaveTypes: leaveTypes,
+	})
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if err = seed.Load(ctx, gdb, dataset); err != nil {
+		return err
+	}
+	logger.Info("seeded the database", "profile", profile.Name, "seed", *rngSeed,
+		"employees", dataset.Employees(), "dayOffs", dataset.DayOffs(), "took", time.Since(start).String())
+	return nil
+}
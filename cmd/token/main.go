@@ -0,0 +1,30 @@
+// Command token issues HS256 tokens for local development against the HR system.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joremysh/fliqt/internal/auth"
+)
+
+func main() {
+	employeeID := flag.Uint("employee", 1, "employee id of the caller")
+	role := flag.String("role", auth.RoleEmployee, "role of the caller: employee, manager or hr_admin")
+	ttl := flag.Duration("ttl", 24*time.Hour, "token lifetime")
+	flag.Parse()
+
+	secret := os.Getenv("JWT_HMAC_SECRET")
+	if secret == "" {
+		log.Fatal("JWT_HMAC_SECRET is required")
+	}
+
+	token, err := auth.SignHS256([]byte(secret), &auth.Principal{EmployeeID: *employeeID, Role: *role}, *ttl)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	fmt.Println(token)
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	RoleEmployee = "employee"
+	RoleManager  = "manager"
+	RoleHRAdmin  = "hr_admin"
+)
+
+var (
+	ErrUnauthenticated = errors.New("missing or invalid bearer token")
+	ErrForbidden       = errors.New("role is not allowed to perform this operation")
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	EmployeeID uint
+	Role       string
+}
+
+func (p *Principal) IsHRAdmin() bool {
+	return p.Role == RoleHRAdmin
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller of the request. It is absent for internal callers
+// such as seeds and background jobs, which are trusted.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
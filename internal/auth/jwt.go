@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the JWT claims issued to HR system users.
+type Claims struct {
+	jwt.RegisteredClaims
+	EmployeeID uint   `json:"employee_id"`
+	Role       string `json:"role"`
+}
+
+type Config struct {
+	// HMACSecret enables HS256 tokens.
+	HMACSecret []byte
+	// RSAPublicKey enables RS256 tokens.
+	RSAPublicKey *rsa.PublicKey
+	Issuer       string
+	Audience     string
+}
+
+// LoadRSAPublicKey reads a PEM encoded RSA public key.
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPublicKeyFromPEM(pem)
+}
+
+// LoadHMACSecret reads a shared secret from a file, ignoring surrounding whitespace.
+func LoadHMACSecret(path string) ([]byte, error) {
+	secret, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSpace(string(secret))), nil
+}
+
+type Verifier struct {
+	config Config
+	parser *jwt.Parser
+}
+
+func NewVerifier(config Config) (*Verifier, error) {
+	var methods []string
+	if len(config.HMACSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if config.RSAPublicKey != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no JWT verification key configured")
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+	return &Verifier{config: config, parser: jwt.NewParser(options...)}, nil
+}
+
+// Verify validates the signature and standard claims of a token and returns its caller.
+func (v *Verifier) Verify(tokenString string) (*Principal, error) {
+	claims := &Claims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, v.key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, err.Error())
+	}
+
+	switch claims.Role {
+	case RoleEmployee, RoleManager, RoleHRAdmin:
+	default:
+		return nil, fmt.Errorf("%w: unknown role %q", ErrUnauthenticated, claims.Role)
+	}
+	if claims.EmployeeID == 0 {
+		return nil, fmt.Errorf("%w: missing employee_id claim", ErrUnauthenticated)
+	}
+	return &Principal{EmployeeID: claims.EmployeeID, Role: claims.Role}, nil
+}
+
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.config.HMACSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		return v.config.RSAPublicKey, nil
+	}
+	return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+}
+
+// SignHS256 issues a token for the given caller. It is meant for development and tests,
+// production tokens come from the identity provider.
+func SignHS256(secret []byte, principal *Principal, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   fmt.Sprintf("%d", principal.EmployeeID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		EmployeeID: principal.EmployeeID,
+		Role:       principal.Role,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier_HS256(t *testing.T) {
+	secret := []byte("secret")
+	verifier, err := NewVerifier(Config{HMACSecret: secret})
+	require.NoError(t, err)
+
+	token, err := SignHS256(secret, &Principal{EmployeeID: 7, Role: RoleManager}, time.Minute)
+	require.NoError(t, err)
+	principal, err := verifier.Verify(token)
+	require.NoError(t, err)
+	require.Equal(t, uint(7), principal.EmployeeID)
+	require.Equal(t, RoleManager, principal.Role)
+
+	expired, err := SignHS256(secret, &Principal{EmployeeID: 7, Role: RoleManager}, -time.Minute)
+	require.NoError(t, err)
+	_, err = verifier.Verify(expired)
+	require.ErrorIs(t, err, ErrUnauthenticated)
+
+	forged, err := SignHS256([]byte("other"), &Principal{EmployeeID: 7, Role: RoleHRAdmin}, time.Minute)
+	require.NoError(t, err)
+	_, err = verifier.Verify(forged)
+	require.ErrorIs(t, err, ErrUnauthenticated)
+
+	unknownRole, err := SignHS256(secret, &Principal{EmployeeID: 7, Role: "root"}, time.Minute)
+	require.NoError(t, err)
+	_, err = verifier.Verify(unknownRole)
+	require.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestVerifier_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, err := NewVerifier(Config{RSAPublicKey: &key.PublicKey, Issuer: "idp"})
+	require.NoError(t, err)
+
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "idp",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+		EmployeeID: 3,
+		Role:       RoleHRAdmin,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	require.NoError(t, err)
+	principal, err := verifier.Verify(token)
+	require.NoError(t, err)
+	require.True(t, principal.IsHRAdmin())
+
+	// HS256 is not enabled without a shared secret
+	hsToken, err := SignHS256([]byte("secret"), &Principal{EmployeeID: 3, Role: RoleHRAdmin}, time.Minute)
+	require.NoError(t, err)
+	_, err = verifier.Verify(hsToken)
+	require.ErrorIs(t, err, ErrUnauthenticated)
+}
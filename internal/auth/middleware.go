@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	middleware "github.com/oapi-codegen/gin-middleware"
+)
+
+// ErrorKey is the gin context key under which a failed authentication stores its error,
+// so that the validation error handler can answer with 401/403 instead of 400.
+const ErrorKey = "auth/error"
+
+// NewAuthenticationFunc validates bearer tokens for operations declaring a security
+// requirement. Scopes of the requirement list the roles allowed to call the operation,
+// an empty list allows every authenticated caller.
+func NewAuthenticationFunc(verifier *Verifier) openapi3filter.AuthenticationFunc {
+	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+		gc := middleware.GetGinContext(ctx)
+		if gc == nil {
+			return fmt.Errorf("gin context missing from validation context")
+		}
+		fail := func(err error) error {
+			gc.Set(ErrorKey, err)
+			return err
+		}
+
+		header := input.RequestValidationInput.Request.Header.Get("Authorization")
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			return fail(ErrUnauthenticated)
+		}
+		principal, err := verifier.Verify(token)
+		if err != nil {
+			return fail(err)
+		}
+		if len(input.Scopes) > 0 && !slices.Contains(input.Scopes, principal.Role) {
+			return fail(ErrForbidden)
+		}
+
+		gc.Request = gc.Request.WithContext(WithPrincipal(gc.Request.Context(), principal))
+		return nil
+	}
+}
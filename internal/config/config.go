@@ -0,0 +1,399 @@
+// Package config loads the server configuration. Values are taken, in increasing order of
+// precedence, from the defaults, a YAML file, environment variables and command line flags.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server   Server   `yaml:"server"`
+	Database Database `yaml:"database"`
+	Redis    Redis    `yaml:"redis"`
+	Cache    Cache    `yaml:"cache"`
+	Log      Log      `yaml:"log"`
+	JWT      JWT      `yaml:"jwt"`
+	Tracing  Tracing  `yaml:"tracing"`
+	Features Features `yaml:"features"`
+	// WorkSchedule is the working time leave is measured in.
+	WorkSchedule WorkSchedule `yaml:"workSchedule"`
+}
+
+type Server struct {
+	Port              int           `yaml:"port" env:"PORT"`
+	ReadTimeout       time.Duration `yaml:"readTimeout" env:"SERVER_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout" env:"SERVER_IDLE_TIMEOUT"`
+	// ShutdownDelay is how long the server keeps serving with a failing readiness probe before
+	// it starts draining, ShutdownTimeout how long in-flight requests may take to complete.
+	ShutdownDelay   time.Duration `yaml:"shutdownDelay" env:"SERVER_SHUTDOWN_DELAY"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+}
+
+type Database struct {
+	DSN             string        `yaml:"dsn" env:"DSN"`
+	MaxOpenConns    int           `yaml:"maxOpenConns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"maxIdleConns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime" env:"DB_CONN_MAX_IDLE_TIME"`
+	// QueryTimeout bounds every statement unless QueryTimeouts has an entry for its operation,
+	// e.g. "query" or "day_off_records.query". From the environment: "query=2s,raw=30s".
+	QueryTimeout  time.Duration            `yaml:"queryTimeout" env:"DB_QUERY_TIMEOUT"`
+	QueryTimeouts map[string]time.Duration `yaml:"queryTimeouts" env:"DB_QUERY_TIMEOUTS"`
+}
+
+type Redis struct {
+	Host     string `yaml:"host" env:"REDIS_HOST"`
+	Port     int    `yaml:"port" env:"REDIS_PORT"`
+	Username string `yaml:"username" env:"REDIS_USERNAME"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD"`
+	DB       int    `yaml:"db" env:"REDIS_DB"`
+	TLS      bool   `yaml:"tls" env:"REDIS_TLS"`
+	// TLSServerName and TLSCAFile override the host name and the system roots used to verify the server.
+	TLSServerName string `yaml:"tlsServerName" env:"REDIS_TLS_SERVER_NAME"`
+	TLSCAFile     string `yaml:"tlsCAFile" env:"REDIS_TLS_CA_FILE"`
+}
+
+type Cache struct {
+	// Backend is redis, memory or none.
+	Backend      string        `yaml:"backend" env:"CACHE_BACKEND"`
+	MemorySize   int           `yaml:"memorySize" env:"CACHE_MEMORY_SIZE"`
+	MemoryMaxTTL time.Duration `yaml:"memoryMaxTTL" env:"CACHE_MEMORY_MAX_TTL"`
+}
+
+type Log struct {
+	// Level is debug, info, warn or error.
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// Levels overrides Level for the app, http, sql and cache components. SQL statements
+	// are logged at debug level. From the environment: "sql=debug,http=warn".
+	Levels map[string]string `yaml:"levels" env:"LOG_LEVELS"`
+	// SlowQuery is the duration above which statements are logged as warnings.
+	SlowQuery time.Duration `yaml:"slowQuery" env:"LOG_SLOW_QUERY"`
+	// RedactSQL logs statements with placeholders instead of their arguments.
+	RedactSQL bool `yaml:"redactSQL" env:"LOG_REDACT_SQL"`
+}
+
+type JWT struct {
+	HMACSecret       string `yaml:"hmacSecret" env:"JWT_HMAC_SECRET"`
+	HMACSecretFile   string `yaml:"hmacSecretFile" env:"JWT_HMAC_SECRET_FILE"`
+	RSAPublicKeyFile string `yaml:"rsaPublicKeyFile" env:"JWT_RSA_PUBLIC_KEY_FILE"`
+	Issuer           string `yaml:"issuer" env:"JWT_ISSUER"`
+	Audience         string `yaml:"audience" env:"JWT_AUDIENCE"`
+}
+
+type Tracing struct {
+	// Exporter is none, otlp or stdout.
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER"`
+	OTLPEndpoint string  `yaml:"otlpEndpoint" env:"TRACING_OTLP_ENDPOINT"`
+	File         string  `yaml:"file" env:"TRACING_FILE"`
+	SampleRatio  float64 `yaml:"sampleRatio" env:"TRACING_SAMPLE_RATIO"`
+	ServiceName  string  `yaml:"serviceName" env:"TRACING_SERVICE_NAME"`
+}
+
+// WorkSchedule holds the working hours as clock times, e.g. "09:00", in TimeZone, an IANA name.
+type WorkSchedule struct {
+	// WorkingDays is a comma separated list of weekdays, e.g. "Mon,Tue,Wed,Thu,Fri".
+	WorkingDays string `yaml:"workingDays" env:"WORK_DAYS"`
+	DayStart    string `yaml:"dayStart" env:"WORK_DAY_START"`
+	DayEnd      string `yaml:"dayEnd" env:"WORK_DAY_END"`
+	LunchStart  string `yaml:"lunchStart" env:"WORK_LUNCH_START"`
+	LunchEnd    string `yaml:"lunchEnd" env:"WORK_LUNCH_END"`
+	TimeZone    string `yaml:"timeZone" env:"WORK_TIME_ZONE"`
+}
+
+type Features struct {
+	// AccessLog logs every request.
+	AccessLog bool `yaml:"accessLog" env:"FEATURE_ACCESS_LOG"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Port:              8080,
+			ReadTimeout:       10 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownDelay:     5 * time.Second,
+			ShutdownTimeout:   15 * time.Second,
+		},
+		Database: Database{
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: time.Hour,
+			QueryTimeout:    10 * time.Second,
+		},
+		Redis: Redis{
+			Host: "localhost",
+			Port: 6379,
+		},
+		Cache: Cache{
+			Backend:      "redis",
+			MemorySize:   10000,
+			MemoryMaxTTL: 10 * time.Minute,
+		},
+		Log: Log{
+			Level:     "info",
+			Levels:    map[string]string{"sql": "warn"},
+			SlowQuery: 200 * time.Millisecond,
+			RedactSQL: true,
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			SampleRatio: 1,
+			ServiceName: "hrs",
+		},
+		Features: Features{
+			AccessLog: true,
+		},
+		WorkSchedule: WorkSchedule{
+			WorkingDays: "Mon,Tue,Wed,Thu,Fri",
+			DayStart:    "09:00",
+			DayEnd:      "18:00",
+			LunchStart:  "12:00",
+			LunchEnd:    "13:00",
+			TimeZone:    "UTC",
+		},
+	}
+}
+
+// Load builds the configuration from the command line arguments, without the program name,
+// and returns it with the arguments left after the flags. The file is given by -config or
+// CONFIG_FILE; every other value can be set with a flag named after its YAML path, e.g.
+// -redis.db=2. The configuration is not validated, callers check the parts they use.
+func Load(args []string) (*Config, []string, error) {
+	config := Default()
+
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	path := flags.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML configuration file")
+	// flags are applied last, but parsed first to find the file
+	var flagValues []func() error
+	visit(reflect.ValueOf(config).Elem(), "", func(name, env string, field reflect.Value) {
+		usage := "sets " + name
+		if env != "" {
+			usage += ", overrides $" + env
+		}
+		flags.Func(name, usage, func(raw string) error {
+			if err := setField(reflect.New(field.Type()).Elem(), raw); err != nil {
+				return err
+			}
+			flagValues = append(flagValues, func() error { return setField(field, raw) })
+			return nil
+		})
+	})
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *path != "" {
+		if err := loadFile(config, *path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var errs []error
+	visit(reflect.ValueOf(config).Elem(), "", func(name, env string, field reflect.Value) {
+		raw, ok := os.LookupEnv(env)
+		if env == "" || !ok {
+			return
+		}
+		if err := setField(field, raw); err != nil {
+			errs = append(errs, fmt.Errorf("$%s: %w", env, err))
+		}
+	})
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	for _, apply := range flagValues {
+		if err := apply(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return config, flags.Args(), nil
+}
+
+func loadFile(config *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	// a misspelled key would otherwise be silently ignored
+	decoder.KnownFields(true)
+	if err = decoder.Decode(config); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every invalid value at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port < 65536, "server.port: must be between 1 and 65535")
+	check(c.Server.ReadTimeout >= 0 && c.Server.ReadHeaderTimeout >= 0 && c.Server.WriteTimeout >= 0 && c.Server.IdleTimeout >= 0,
+		"server: timeouts must not be negative")
+	check(c.Server.ShutdownDelay >= 0, "server.shutdownDelay: must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout: must be positive")
+
+	if err := c.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	check(oneOf(c.Cache.Backend, "redis", "memory", "none"), "cache.backend: must be redis, memory or none")
+	if c.Cache.Backend == "redis" {
+		check(c.Redis.Host != "", "redis.host: is required by the redis cache backend")
+		check(c.Redis.Port > 0 && c.Redis.Port < 65536, "redis.port: must be between 1 and 65535")
+		check(c.Redis.DB >= 0, "redis.db: must not be negative")
+	}
+	if c.Cache.Backend == "memory" {
+		check(c.Cache.MemorySize > 0, "cache.memorySize: must be positive")
+	}
+
+	if err := c.Log.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	check(oneOf(c.Tracing.Exporter, "none", "otlp", "stdout"), "tracing.exporter: must be none, otlp or stdout")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio: must be between 0 and 1")
+	check(c.Tracing.ServiceName != "", "tracing.serviceName: is required")
+
+	check(c.JWT.HMACSecret != "" || c.JWT.HMACSecretFile != "" || c.JWT.RSAPublicKeyFile != "",
+		"jwt: one of hmacSecret, hmacSecretFile or rsaPublicKeyFile is required")
+
+	return errors.Join(errs...)
+}
+
+// Validate checks the database settings, which is all the migrate command needs.
+func (d Database) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(d.DSN != "", "database.dsn: is required")
+	check(d.MaxOpenConns >= 0, "database.maxOpenConns: must not be negative")
+	check(d.MaxIdleConns >= 0, "database.maxIdleConns: must not be negative")
+	check(d.MaxOpenConns == 0 || d.MaxIdleConns <= d.MaxOpenConns,
+		"database.maxIdleConns: must not exceed maxOpenConns")
+	check(d.QueryTimeout >= 0, "database.queryTimeout: must not be negative")
+	for operation, timeout := range d.QueryTimeouts {
+		check(timeout >= 0, "database.queryTimeouts.%s: must not be negative", operation)
+	}
+	return errors.Join(errs...)
+}
+
+func (l Log) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(oneOf(l.Level, "debug", "info", "warn", "error"), "log.level: must be debug, info, warn or error")
+	for component, level := range l.Levels {
+		check(oneOf(component, "app", "http", "sql", "cache"), "log.levels.%s: unknown component, must be app, http, sql or cache", component)
+		check(oneOf(level, "debug", "info", "warn", "error"), "log.levels.%s: must be debug, info, warn or error", component)
+	}
+	check(l.SlowQuery >= 0, "log.slowQuery: must not be negative")
+	return errors.Join(errs...)
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+// visit calls fn for every leaf field with its dotted YAML path and environment variable.
+func visit(value reflect.Value, prefix string, fn func(name, env string, field reflect.Value)) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := prefix + field.Tag.Get("yaml")
+		if field.Type.Kind() == reflect.Struct {
+			visit(value.Field(i), name+".", fn)
+			continue
+		}
+		fn(name, field.Tag.Get("env"), value.Field(i))
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setField(field reflect.Value, raw string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Map:
+		// maps are written as "key=value,key=value" and replace the defaults as a whole
+		values := reflect.MakeMap(field.Type())
+		for _, pair := range strings.Split(raw, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("expected key=value, got %q", pair)
+			}
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setField(elem, strings.TrimSpace(value)); err != nil {
+				return err
+			}
+			values.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)), elem)
+		}
+		field.Set(values)
+	default:
+		return fmt.Errorf("unsupported config field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, `
+server:
+  port: 9000
+  writeTimeout: 45s
+database:
+  dsn: file-dsn
+  maxOpenConns: 50
+redis:
+  db: 1
+jwt:
+  hmacSecret: secret
+`)
+	t.Setenv("REDIS_DB", "2")
+	t.Setenv("DSN", "env-dsn")
+
+	cfg, _, err := Load([]string{"-config", path, "-redis.db=3"})
+	require.NoError(t, err)
+	require.Equal(t, 9000, cfg.Server.Port)
+	require.Equal(t, 45*time.Second, cfg.Server.WriteTimeout)
+	require.Equal(t, 50, cfg.Database.MaxOpenConns)
+	require.Equal(t, "env-dsn", cfg.Database.DSN)
+	require.Equal(t, 3, cfg.Redis.DB)
+	// untouched values keep their defaults
+	require.Equal(t, 10, cfg.Database.MaxIdleConns)
+	require.Equal(t, "redis", cfg.Cache.Backend)
+}
+
+func TestLoad_ConfigFileFromEnvironment(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeFile(t, "database:\n  dsn: dsn\njwt:\n  hmacSecret: secret\ncache:\n  backend: none\n"))
+
+	cfg, _, err := Load(nil)
+	require.NoError(t, err)
+	require.Equal(t, "none", cfg.Cache.Backend)
+}
+
+func TestLoad_RejectsUnknownKeys(t *testing.T) {
+	path := writeFile(t, "database:\n  dns: typo\n")
+
+	_, _, err := Load([]string{"-config", path})
+	require.ErrorContains(t, err, "dns")
+}
+
+func TestLoad_RejectsInvalidValues(t *testing.T) {
+	_, _, err := Load([]string{"-server.port=http"})
+	require.Error(t, err)
+
+	t.Setenv("REDIS_TLS", "maybe")
+	_, _, err = Load([]string{"-database.dsn=dsn", "-jwt.hmacSecret=secret"})
+	require.ErrorContains(t, err, "REDIS_TLS")
+}
+
+func TestLoad_ReturnsArguments(t *testing.T) {
+	cfg, args, err := Load([]string{"-database.dsn=dsn", "migrate", "to", "3"})
+	require.NoError(t, err)
+	require.Equal(t, "dsn", cfg.Database.DSN)
+	require.Equal(t, []string{"migrate", "to", "3"}, args)
+	// the migrate command only needs the database
+	require.NoError(t, cfg.Database.Validate())
+	require.ErrorContains(t, cfg.Validate(), "jwt")
+}
+
+func TestLoad_Maps(t *testing.T) {
+	t.Setenv("LOG_LEVELS", "sql=debug, http=warn")
+	t.Setenv("DB_QUERY_TIMEOUTS", "query=2s,raw=30s")
+
+	cfg, _, err := Load([]string{"-database.dsn=dsn", "-jwt.hmacSecret=secret"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sql": "debug", "http": "warn"}, cfg.Log.Levels)
+	require.Equal(t, map[string]time.Duration{"query": 2 * time.Second, "raw": 30 * time.Second}, cfg.Database.QueryTimeouts)
+
+	t.Setenv("LOG_LEVELS", "sql")
+	_, _, err = Load([]string{"-database.dsn=dsn", "-jwt.hmacSecret=secret"})
+	require.ErrorContains(t, err, "LOG_LEVELS")
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.Database.MaxIdleConns = 30
+	cfg.Cache.Backend = "memcached"
+	cfg.Log.Level = "verbose"
+	cfg.Log.Levels = map[string]string{"grpc": "info"}
+
+	err := cfg.Validate()
+	require.ErrorContains(t, err, "database.dsn")
+	require.ErrorContains(t, err, "database.maxIdleConns")
+	require.ErrorContains(t, err, "cache.backend")
+	require.ErrorContains(t, err, "log.level")
+	require.ErrorContains(t, err, "log.levels.grpc")
+	require.ErrorContains(t, err, "jwt")
+
+	cfg = Default()
+	cfg.Database.DSN = "dsn"
+	cfg.JWT.RSAPublicKeyFile = "key.pem"
+	require.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/auth"
+	"github.com/joremysh/fliqt/internal/service"
+)
+
+const (
+	codeInvalidRequest  = "invalid_request"
+	codeNotFound        = "not_found"
+	codeUnauthenticated = "unauthenticated"
+	codeForbidden       = "forbidden"
+	codeInternalError   = "internal_error"
+	codeCancelled       = "request_cancelled"
+	codeTimeout         = "timeout"
+)
+
+// statusClientClosedRequest is the non-standard status, borrowed from nginx, recorded when the
+// client went away before the response was ready. It keeps disconnects out of the 5xx rate.
+const statusClientClosedRequest = 499
+
+var statusByKind = map[service.ErrorKind]int{
+	service.KindNotFound:   http.StatusNotFound,
+	service.KindConflict:   http.StatusConflict,
+	service.KindValidation: http.StatusUnprocessableEntity,
+	service.KindForbidden:  http.StatusForbidden,
+}
+
+func sendErrorResponse(c *gin.Context, status int, code string, errMsg string) {
+	c.JSON(status, api.Error{
+		Status:  status,
+		Code:    code,
+		Message: errMsg,
+	})
+}
+
+// handleServiceError maps domain errors to their HTTP status and code. Cancelled and timed out
+// requests get their own status, anything else is reported as an internal error without
+// leaking its message to the client.
+func handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		sendErrorResponse(c, statusClientClosedRequest, codeCancelled, "request cancelled")
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		_ = c.Error(err)
+		sendErrorResponse(c, http.StatusGatewayTimeout, codeTimeout, "request timed out")
+		return
+	}
+
+	var domainErr *service.Error
+	if !errors.As(err, &domainErr) {
+		_ = c.Error(err)
+		// the cause only goes to the trace, never to the client
+		trace.SpanFromContext(c.Request.Context()).RecordError(err)
+		sendErrorResponse(c, http.StatusInternalServerError, codeInternalError, "internal server error")
+		return
+	}
+
+	status, ok := statusByKind[domainErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	resp := api.Error{
+		Status:  status,
+		Code:    domainErr.Code,
+		Message: err.Error(),
+	}
+	if domainErr.Field != "" {
+		resp.Details = &[]api.ErrorDetail{{
+			Field:   domainErr.Field,
+			Code:    domainErr.Code,
+			Message: domainErr.Message,
+		}}
+	}
+	c.JSON(status, resp)
+}
+
+// ValidationErrorHandler renders OpenAPI request validation failures with the common error body.
+// Failed authentication is reported by the validator as a bad request, it is answered with 401/403.
+func ValidationErrorHandler(c *gin.Context, message string, statusCode int) {
+	code := codeInvalidRequest
+	if statusCode == http.StatusNotFound {
+		code = codeNotFound
+	}
+	if value, ok := c.Get(auth.ErrorKey); ok {
+		authErr := value.(error)
+		statusCode, code = http.StatusUnauthorized, codeUnauthenticated
+		if errors.Is(authErr, auth.ErrForbidden) {
+			statusCode, code = http.StatusForbidden, codeForbidden
+		}
+		message = authErr.Error()
+	}
+	c.AbortWithStatusJSON(statusCode, api.Error{
+		Status:  statusCode,
+		Code:    code,
+		Message: message,
+	})
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/service"
+)
+
+func TestHandleServiceError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantField  string
+	}{
+		{"not found", fmt.Errorf("%w by id: %d", service.ErrEmployeeNotFound, 1), http.StatusNotFound, "employee_not_found", ""},
+		{"conflict", fmt.Errorf("%w: a@b.c", service.ErrEmailAlreadyExists), http.StatusConflict, "email_already_exists", "email"},
+		{"validation", service.ErrInvalidDateRange, http.StatusUnprocessableEntity, "invalid_date_range", "endTime"},
+		{"forbidden", service.ErrSelfReview, http.StatusForbidden, "self_review", ""},
+		{"internal", errors.New("connection refused"), http.StatusInternalServerError, codeInternalError, ""},
+		{"cancelled", fmt.Errorf("%w: invalid connection", context.Canceled), statusClientClosedRequest, codeCancelled, ""},
+		{"timeout", fmt.Errorf("%w: invalid connection", context.DeadlineExceeded), http.StatusGatewayTimeout, codeTimeout, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			handleServiceError(c, tt.err)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			var resp api.Error
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Equal(t, tt.wantStatus, resp.Status)
+			require.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantField == "" {
+				require.Nil(t, resp.Details)
+				return
+			}
+			require.Len(t, *resp.Details, 1)
+			require.Equal(t, tt.wantField, (*resp.Details)[0].Field)
+		})
+	}
+}
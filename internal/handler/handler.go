@@ -1,16 +1,23 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	openapitypes "github.com/oapi-codegen/runtime/types"
 	"gorm.io/gorm"
 
 	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/auth"
 	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/policy"
 	"github.com/joremysh/fliqt/internal/repository"
 	"github.com/joremysh/fliqt/internal/service"
+	"github.com/joremysh/fliqt/internal/worktime"
 	"github.com/joremysh/fliqt/pkg/cache"
 )
 
@@ -18,19 +25,40 @@ var _ api.ServerInterface = (*HRSystem)(nil)
 var StartUp string
 
 type HRSystem struct {
-	gdb             *gorm.DB
-	employeeService service.EmployeeService
-	dayOffService   service.DayOffService
+	gdb                   *gorm.DB
+	employeeService       service.EmployeeService
+	dayOffService         service.DayOffService
+	leaveBalanceService   service.LeaveBalanceService
+	auditService          service.AuditService
+	employeeImportService service.EmployeeImportService
+	holidayService        service.HolidayService
+	leaveTypeService      service.LeaveTypeService
+	dependencies          []dependency
+	ready                 atomic.Bool
 }
 
-func NewHRSystem(gdb *gorm.DB, redisClient *cache.RedisClient) *HRSystem {
+// NewHRSystem builds the handlers. Day off requests are measured in the working time of schedule.
+func NewHRSystem(gdb *gorm.DB, employeeCache cache.Cache, schedule worktime.Schedule) *HRSystem {
 	employeeRepo := repository.NewEmployeeRepo(gdb)
 	dayOffRepo := repository.NewDayOffRepo(gdb)
+	leaveTypeRepo := repository.NewLeaveTypeRepo(gdb)
+	leaveBalanceService := service.NewLeaveBalanceService(repository.NewLeaveBalanceRepo(gdb), employeeRepo, leaveTypeRepo)
+	auditService := service.NewAuditService(repository.NewAuditRepo(gdb))
+	transactor := repository.NewTransactor(gdb)
+
+	holidayRepo := repository.NewHolidayRepo(gdb)
+	dayOffService := service.NewDayOffService(dayOffRepo, transactor, employeeRepo, holidayRepo, leaveTypeRepo, leaveBalanceService, auditService, schedule)
 
 	return &HRSystem{
-		gdb:             gdb,
-		employeeService: service.NewEmployeeService(employeeRepo, redisClient),
-		dayOffService:   service.NewDayOffService(dayOffRepo, employeeRepo),
+		gdb:                   gdb,
+		employeeService:       service.NewEmployeeService(employeeRepo, transactor, employeeCache, auditService, dayOffService),
+		dayOffService:         dayOffService,
+		leaveBalanceService:   leaveBalanceService,
+		auditService:          auditService,
+		employeeImportService: service.NewEmployeeImportService(repository.NewEmployeeImportRepo(gdb), transactor, employeeRepo, auditService, employeeCache),
+		holidayService:        service.NewHolidayService(holidayRepo, transactor, auditService),
+		leaveTypeService:      service.NewLeaveTypeService(leaveTypeRepo, transactor, auditService),
+		dependencies:          newDependencies(gdb, employeeCache),
 	}
 }
 
@@ -40,35 +68,119 @@ func (s *HRSystem) GetLiveness(c *gin.Context) {
 	})
 }
 
-func ConvertToEmployeeResponse(employee *model.Employee) *api.Employee {
-	return &api.Employee{
-		Address:     employee.Address,
+// ConvertToEmployeeResponse converts an employee, omitting the sensitive fields that are not visible.
+func ConvertToEmployeeResponse(employee *model.Employee, visible policy.FieldSet) *api.Employee {
+	var managerID *int64
+	if employee.ManagerID != nil {
+		id := int64(*employee.ManagerID)
+		managerID = &id
+	}
+	resp := &api.Employee{
 		Email:       openapitypes.Email(employee.Email),
 		Id:          int64(employee.ID),
 		Name:        employee.Name,
 		OnboardDate: openapitypes.Date{Time: employee.OnboardDate},
-		PhoneNumber: employee.PhoneNumber,
-		Salary:      employee.Salary,
 		Department:  api.EmployeeDepartment(employee.Department),
 		Title:       employee.Title,
 		Level:       employee.Level,
+		ManagerID:   managerID,
+	}
+	if visible[policy.FieldAddress] {
+		resp.Address = &employee.Address
+	}
+	if visible[policy.FieldPhoneNumber] {
+		resp.PhoneNumber = &employee.PhoneNumber
+	}
+	if visible[policy.FieldSalary] {
+		resp.Salary = &employee.Salary
+	}
+	if employee.Region != "" {
+		resp.Region = &employee.Region
 	}
+	if employee.EmploymentStatus != "" {
+		status := api.EmployeeEmploymentStatus(employee.EmploymentStatus)
+		resp.EmploymentStatus = &status
+	}
+	if employee.TerminationDate != nil {
+		resp.TerminationDate = &openapitypes.Date{Time: *employee.TerminationDate}
+	}
+	if visible[policy.FieldTerminationReason] && employee.TerminationReason != "" {
+		resp.TerminationReason = &employee.TerminationReason
+	}
+	return resp
+}
+
+func ConvertToOrgChartResponse(ctx context.Context, node *service.OrgChartNode) api.OrgChartNode {
+	resp := api.OrgChartNode{
+		Employee: *ConvertToEmployeeResponse(&node.Employee, policy.VisibleFields(ctx, &node.Employee)),
+		Reports:  make([]api.OrgChartNode, len(node.Reports)),
+	}
+	for i, report := range node.Reports {
+		resp.Reports[i] = ConvertToOrgChartResponse(ctx, report)
+	}
+	return resp
+}
+
+func parseRegion(region *api.Region) string {
+	if region == nil {
+		return ""
+	}
+	return *region
+}
+
+func parseManagerID(managerID *int64) *uint {
+	if managerID == nil {
+		return nil
+	}
+	id := uint(*managerID)
+	return &id
 }
 
 func ConvertToDayOffResponse(record *model.DayOffRecord) *api.DayOffRecord {
-	return &api.DayOffRecord{
-		DayOffType: api.DayOffRecordDayOffType(record.DayOffType),
-		EmployeeID: int64(record.EmployeeID),
-		EndTime:    record.EndTime,
-		Reason:     record.Reason,
-		StartTime:  record.StartTime,
+	id := int64(record.ID)
+	status := api.DayOffRecordStatus(record.Status)
+	resp := &api.DayOffRecord{
+		Id:            &id,
+		DayOffType:    record.DayOffType,
+		EmployeeID:    int64(record.EmployeeID),
+		EndTime:       record.EndTime,
+		Reason:        record.Reason,
+		StartTime:     record.StartTime,
+		DurationHours: &record.DurationHours,
+		DurationDays:  &record.DurationDays,
+		Status:        &status,
+		SubmittedAt:   &record.CreatedAt,
+		ReviewedAt:    record.ReviewedAt,
+		CancelledAt:   record.CancelledAt,
+	}
+	if record.AttachmentURL != "" {
+		resp.AttachmentURL = &record.AttachmentURL
+	}
+	if record.HalfDay != "" {
+		halfDay := api.DayOffRecordHalfDay(record.HalfDay)
+		resp.HalfDay = &halfDay
 	}
+	if record.ReviewerID != nil {
+		reviewerID := int64(*record.ReviewerID)
+		resp.ReviewerID = &reviewerID
+	}
+	if record.ReviewComment != "" {
+		resp.ReviewComment = &record.ReviewComment
+	}
+	if record.CancelledBy != nil {
+		cancelledBy := int64(*record.CancelledBy)
+		resp.CancelledBy = &cancelledBy
+	}
+	if record.CancellationReason != "" {
+		resp.CancellationReason = &record.CancellationReason
+	}
+	return resp
 }
 
 func (s *HRSystem) ListEmployees(c *gin.Context, params api.ListEmployeesParams) {
 	result, err := s.employeeService.ListEmployees(c.Request.Context(), parseListParams(params))
 	if err != nil {
-		sendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		handleServiceError(c, err)
 		return
 	}
 
@@ -79,7 +191,7 @@ func (s *HRSystem) ListEmployees(c *gin.Context, params api.ListEmployeesParams)
 		TotalCount: result.TotalCount,
 	}
 	for i, employee := range result.Data {
-		converted := ConvertToEmployeeResponse(&employee)
+		converted := ConvertToEmployeeResponse(&employee, policy.VisibleFields(c.Request.Context(), &employee))
 		resp.Data[i] = *converted
 	}
 	c.JSON(http.StatusOK, resp)
@@ -102,21 +214,17 @@ func parseListParams(params api.ListEmployeesParams) *model.ListParams {
 	if params.Filters != nil {
 		listParams.Filters = *params.Filters
 	}
+	if params.IncludeTerminated != nil {
+		listParams.IncludeTerminated = *params.IncludeTerminated
+	}
 	return listParams
 }
 
-func sendErrorResponse(c *gin.Context, code int, errMsg string) {
-	c.JSON(code, api.Error{
-		Code:    code,
-		Message: errMsg,
-	})
-}
-
 func (s *HRSystem) AddEmployee(c *gin.Context) {
 	var newEmployee api.NewEmployee
 	err := c.Bind(&newEmployee)
 	if err != nil {
-		sendErrorResponse(c, http.StatusBadRequest, "Invalid format for Employee")
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Employee")
 		return
 	}
 
@@ -130,20 +238,22 @@ func (s *HRSystem) AddEmployee(c *gin.Context) {
 		OnboardDate: newEmployee.OnboardDate.Time,
 		Title:       newEmployee.Title,
 		Level:       newEmployee.Level,
+		ManagerID:   parseManagerID(newEmployee.ManagerID),
+		Region:      parseRegion(newEmployee.Region),
 	})
 	if err != nil {
-		sendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, ConvertToEmployeeResponse(created))
+	c.JSON(http.StatusCreated, ConvertToEmployeeResponse(created, policy.VisibleFields(c.Request.Context(), created)))
 }
 
 func (s *HRSystem) UpdateEmployee(c *gin.Context, id int64) {
 	var newEmployee api.NewEmployee
 	err := c.Bind(&newEmployee)
 	if err != nil {
-		sendErrorResponse(c, http.StatusBadRequest, "Invalid format for Employee")
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Employee")
 		return
 	}
 
@@ -157,54 +267,143 @@ func (s *HRSystem) UpdateEmployee(c *gin.Context, id int64) {
 		OnboardDate: newEmployee.OnboardDate.Time,
 		Title:       newEmployee.Title,
 		Level:       newEmployee.Level,
+		ManagerID:   parseManagerID(newEmployee.ManagerID),
+		Region:      parseRegion(newEmployee.Region),
 	}
 	req.ID = uint(id)
 
 	updated, err := s.employeeService.UpdateEmployee(c.Request.Context(), req)
 	if err != nil {
-		sendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, ConvertToEmployeeResponse(updated))
+	c.JSON(http.StatusOK, ConvertToEmployeeResponse(updated, policy.VisibleFields(c.Request.Context(), updated)))
 }
 
 func (s *HRSystem) DeleteEmployee(c *gin.Context, id int64) {
-	if err := s.employeeService.DeleteEmployee(c.Request.Context(), uint(id)); err != nil {
-		sendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	if _, err := s.employeeService.TerminateEmployee(c.Request.Context(), uint(id), time.Now(), ""); err != nil {
+		handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, id)
 }
 
+func (s *HRSystem) TerminateEmployee(c *gin.Context, id int64) {
+	var request api.TerminateEmployeeJSONBody
+	err := c.Bind(&request)
+	if err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Terminate Employee")
+		return
+	}
+
+	var reason string
+	if request.Reason != nil {
+		reason = *request.Reason
+	}
+	terminated, err := s.employeeService.TerminateEmployee(c.Request.Context(), uint(id), request.TerminationDate.Time, reason)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToEmployeeResponse(terminated, policy.VisibleFields(c.Request.Context(), terminated)))
+}
+
+func (s *HRSystem) RehireEmployee(c *gin.Context, id int64) {
+	var request api.RehireEmployeeJSONBody
+	err := c.Bind(&request)
+	if err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Rehire Employee")
+		return
+	}
+
+	rehired, err := s.employeeService.RehireEmployee(c.Request.Context(), uint(id), request.OnboardDate.Time)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToEmployeeResponse(rehired, policy.VisibleFields(c.Request.Context(), rehired)))
+}
+
 func (s *HRSystem) FindEmployeeByID(c *gin.Context, id int64) {
 	employee, err := s.employeeService.GetEmployee(c.Request.Context(), uint(id))
 	if err != nil {
-		sendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, ConvertToEmployeeResponse(employee))
+	c.JSON(http.StatusOK, ConvertToEmployeeResponse(employee, policy.VisibleFields(c.Request.Context(), employee)))
+}
+
+func (s *HRSystem) ListDirectReports(c *gin.Context, id int64, params api.ListDirectReportsParams) {
+	includeTerminated := params.IncludeTerminated != nil && *params.IncludeTerminated
+	reports, err := s.employeeService.ListDirectReports(c.Request.Context(), uint(id), includeTerminated)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := make([]api.Employee, len(reports))
+	for i, employee := range reports {
+		resp[i] = *ConvertToEmployeeResponse(&employee, policy.VisibleFields(c.Request.Context(), &employee))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (s *HRSystem) GetManagementChain(c *gin.Context, id int64) {
+	chain, err := s.employeeService.GetManagementChain(c.Request.Context(), uint(id))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := make([]api.Employee, len(chain))
+	for i, manager := range chain {
+		resp[i] = *ConvertToEmployeeResponse(&manager, policy.VisibleFields(c.Request.Context(), &manager))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (s *HRSystem) GetOrgChart(c *gin.Context, department api.GetOrgChartParamsDepartment, params api.GetOrgChartParams) {
+	includeTerminated := params.IncludeTerminated != nil && *params.IncludeTerminated
+	roots, err := s.employeeService.GetOrgChart(c.Request.Context(), string(department), includeTerminated)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := make([]api.OrgChartNode, len(roots))
+	for i, root := range roots {
+		resp[i] = ConvertToOrgChartResponse(c.Request.Context(), root)
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 func (s *HRSystem) SubmitDayOff(c *gin.Context, id int64) {
 	var dayOffRecord api.DayOffRecord
 	err := c.Bind(&dayOffRecord)
 	if err != nil {
-		sendErrorResponse(c, http.StatusBadRequest, "Invalid format for DayOff Record")
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for DayOff Record")
 		return
 	}
 
-	created, err := s.dayOffService.SubmitDayOff(c.Request.Context(), &model.DayOffRecord{
+	record := &model.DayOffRecord{
 		EmployeeID: uint(id),
-		DayOffType: string(dayOffRecord.DayOffType),
+		DayOffType: dayOffRecord.DayOffType,
 		Reason:     dayOffRecord.Reason,
 		StartTime:  dayOffRecord.StartTime,
 		EndTime:    dayOffRecord.EndTime,
-	})
+	}
+	if dayOffRecord.AttachmentURL != nil {
+		record.AttachmentURL = *dayOffRecord.AttachmentURL
+	}
+	if dayOffRecord.HalfDay != nil {
+		record.HalfDay = string(*dayOffRecord.HalfDay)
+	}
+	created, err := s.dayOffService.SubmitDayOff(c.Request.Context(), record)
 	if err != nil {
-		sendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		handleServiceError(c, err)
 		return
 	}
 
@@ -212,26 +411,39 @@ func (s *HRSystem) SubmitDayOff(c *gin.Context, id int64) {
 }
 
 func (s *HRSystem) ListDayOffs(c *gin.Context, id int64, params api.ListDayOffsParams) {
-	listParams := &model.ListParams{}
+	query := &model.DayOffQuery{
+		Page:       1,
+		PageSize:   10,
+		EmployeeID: uint(id),
+		From:       dateStart(params.From),
+		To:         dateEnd(params.To),
+		StartFrom:  dateStart(params.StartTimeFrom),
+		StartTo:    dateEnd(params.StartTimeTo),
+	}
 	if params.PageSize != nil {
-		listParams.PageSize = *params.PageSize
+		query.PageSize = *params.PageSize
 	}
 	if params.Page != nil {
-		listParams.Page = *params.Page
+		query.Page = *params.Page
 	}
 	if params.SortBy != nil {
-		listParams.SortBy = string(*params.SortBy)
+		query.SortBy = string(*params.SortBy)
 	}
 	if params.SortOrder != nil {
-		listParams.SortOrder = string(*params.SortOrder)
+		query.SortOrder = string(*params.SortOrder)
 	}
 	if params.Filters != nil {
-		listParams.Filters = *params.Filters
+		if params.Filters.DayOffType != nil {
+			query.DayOffType = *params.Filters.DayOffType
+		}
+		if params.Filters.Status != nil {
+			query.Status = string(*params.Filters.Status)
+		}
 	}
 
-	result, err := s.dayOffService.ListDayOffs(c.Request.Context(), uint(id), listParams)
+	result, err := s.dayOffService.ListDayOffs(c.Request.Context(), query)
 	if err != nil {
-		sendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		handleServiceError(c, err)
 		return
 	}
 
@@ -248,17 +460,194 @@ func (s *HRSystem) ListDayOffs(c *gin.Context, id int64, params api.ListDayOffsP
 	c.JSON(http.StatusOK, resp)
 }
 
+// dateStart returns the first instant of a date, dates are UTC.
+func dateStart(date *openapitypes.Date) *time.Time {
+	if date == nil {
+		return nil
+	}
+	return &date.Time
+}
+
+// dateEnd returns the instant after a date, so that ranges include their last day.
+func dateEnd(date *openapitypes.Date) *time.Time {
+	if date == nil {
+		return nil
+	}
+	end := date.Time.AddDate(0, 0, 1)
+	return &end
+}
+
+func (s *HRSystem) GetDayOff(c *gin.Context, id int64) {
+	record, err := s.dayOffService.GetDayOff(c.Request.Context(), uint(id))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToDayOffResponse(record))
+}
+
 func (s *HRSystem) CancelDayOff(c *gin.Context, id int64) {
 	var request api.CancelDayOffJSONBody
 	err := c.Bind(&request)
 	if err != nil {
-		sendErrorResponse(c, http.StatusBadRequest, "Invalid format for Cancel Day Off")
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Cancel Day Off")
 		return
 	}
 
-	if err = s.dayOffService.CancelDayOff(c.Request.Context(), uint(id), request.CancellationReason); err != nil {
-		sendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	cancelled, err := s.dayOffService.CancelDayOff(c.Request.Context(), uint(id), request.CancellationReason)
+	if err != nil {
+		handleServiceError(c, err)
 		return
 	}
-	c.JSON(http.StatusNoContent, id)
+	c.JSON(http.StatusOK, ConvertToDayOffResponse(cancelled))
+}
+
+func (s *HRSystem) ApproveDayOff(c *gin.Context, id int64) {
+	var review api.DayOffReview
+	err := c.Bind(&review)
+	if err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Day Off Review")
+		return
+	}
+
+	principal, ok := auth.PrincipalFromContext(c.Request.Context())
+	if !ok {
+		sendErrorResponse(c, http.StatusUnauthorized, codeUnauthenticated, auth.ErrUnauthenticated.Error())
+		return
+	}
+
+	var comment string
+	if review.Comment != nil {
+		comment = *review.Comment
+	}
+	approved, err := s.dayOffService.ApproveDayOff(c.Request.Context(), uint(id), principal.EmployeeID, comment)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToDayOffResponse(approved))
+}
+
+func (s *HRSystem) RejectDayOff(c *gin.Context, id int64) {
+	var review api.DayOffReview
+	err := c.Bind(&review)
+	if err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Day Off Review")
+		return
+	}
+
+	principal, ok := auth.PrincipalFromContext(c.Request.Context())
+	if !ok {
+		sendErrorResponse(c, http.StatusUnauthorized, codeUnauthenticated, auth.ErrUnauthenticated.Error())
+		return
+	}
+
+	var comment string
+	if review.Comment != nil {
+		comment = *review.Comment
+	}
+	rejected, err := s.dayOffService.RejectDayOff(c.Request.Context(), uint(id), principal.EmployeeID, comment)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToDayOffResponse(rejected))
+}
+
+func (s *HRSystem) ListLeaveBalances(c *gin.Context, id int64, params api.ListLeaveBalancesParams) {
+	year := time.Now().Year()
+	if params.Year != nil {
+		year = *params.Year
+	}
+
+	balances, err := s.leaveBalanceService.ListBalances(c.Request.Context(), uint(id), year)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := &api.ListLeaveBalancesResponse{
+		EmployeeID: id,
+		Year:       year,
+		Data:       make([]api.LeaveBalance, len(balances)),
+	}
+	for i, balance := range balances {
+		resp.Data[i] = api.LeaveBalance{
+			DayOffType:    balance.DayOffType,
+			EntitledDays:  balance.Entitled,
+			UsedDays:      balance.Used,
+			RemainingDays: balance.Remaining,
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func ConvertToAuditEventResponse(event *model.AuditEvent) (*api.AuditEvent, error) {
+	resp := &api.AuditEvent{
+		Id:         int64(event.ID),
+		OccurredAt: event.OccurredAt,
+		EntityType: api.AuditEventEntityType(event.EntityType),
+		EntityID:   int64(event.EntityID),
+		Operation:  api.AuditEventOperation(event.Operation),
+		Changes:    map[string]api.FieldChange{},
+	}
+	if event.ActorID != nil {
+		actorID := int64(*event.ActorID)
+		resp.ActorID = &actorID
+		resp.ActorRole = &event.ActorRole
+	}
+	if event.Changes != "" {
+		if err := json.Unmarshal([]byte(event.Changes), &resp.Changes); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (s *HRSystem) ListAuditEvents(c *gin.Context, params api.ListAuditEventsParams) {
+	query := &model.AuditEventQuery{
+		Page:     1,
+		PageSize: 10,
+		From:     params.From,
+		To:       params.To,
+	}
+	if params.Page != nil {
+		query.Page = *params.Page
+	}
+	if params.PageSize != nil {
+		query.PageSize = *params.PageSize
+	}
+	if params.EntityType != nil {
+		query.EntityType = string(*params.EntityType)
+	}
+	if params.EntityID != nil {
+		entityID := uint(*params.EntityID)
+		query.EntityID = &entityID
+	}
+	if params.ActorID != nil {
+		actorID := uint(*params.ActorID)
+		query.ActorID = &actorID
+	}
+
+	result, err := s.auditService.ListEvents(c.Request.Context(), query)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := &api.ListAuditEventsResponse{
+		Data:       make([]api.AuditEvent, len(result.Data)),
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalCount: result.TotalCount,
+	}
+	for i, event := range result.Data {
+		converted, err := ConvertToAuditEventResponse(&event)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		resp.Data[i] = *converted
+	}
+	c.JSON(http.StatusOK, resp)
 }
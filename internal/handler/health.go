@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/pkg/cache"
+)
+
+// Build information, set at link time with -ldflags "-X".
+var (
+	Version = "dev"
+	Commit  = "unknown"
+)
+
+// healthCheckTimeout bounds each dependency ping, so that a hanging dependency fails the probe
+// instead of stalling it.
+const healthCheckTimeout = 2 * time.Second
+
+// dependency is a backing service the instance talks to. The instance is not ready while a
+// required dependency is down; optional ones only degrade it.
+type dependency struct {
+	name     string
+	required bool
+	ping     func(ctx context.Context) error
+}
+
+func newDependencies(gdb *gorm.DB, employeeCache cache.Cache) []dependency {
+	dependencies := []dependency{{
+		name:     "database",
+		required: true,
+		ping: func(ctx context.Context) error {
+			db, err := gdb.DB()
+			if err != nil {
+				return err
+			}
+			return db.PingContext(ctx)
+		},
+	}}
+	// the in-memory and no-op caches have nothing to reach
+	if pinger, ok := employeeCache.(cache.Pinger); ok {
+		dependencies = append(dependencies, dependency{name: "redis", ping: pinger.Ping})
+	}
+	return dependencies
+}
+
+// SetReady controls the readiness probe. The server is marked not ready before it starts
+// draining, so that load balancers stop routing new requests to it.
+func (s *HRSystem) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+func (s *HRSystem) GetReadiness(c *gin.Context) {
+	checks, requiredUp, _ := s.checkDependencies(c.Request.Context())
+	// the probe is public, the errors name the addresses of the dependencies
+	for name, result := range checks {
+		result.Error = nil
+		checks[name] = result
+	}
+	if !s.ready.Load() || !requiredUp {
+		c.JSON(http.StatusServiceUnavailable, api.Readiness{Status: api.NotReady, Checks: checks})
+		return
+	}
+	c.JSON(http.StatusOK, api.Readiness{Status: api.Ready, Checks: checks})
+}
+
+func (s *HRSystem) GetHealth(c *gin.Context) {
+	checks, requiredUp, allUp := s.checkDependencies(c.Request.Context())
+	health := api.Health{
+		Status:    api.HealthStatusUp,
+		Version:   Version,
+		Commit:    Commit,
+		StartTime: StartUp,
+		Checks:    checks,
+	}
+	if db, err := s.gdb.DB(); err == nil {
+		stats := db.Stats()
+		health.DatabasePool = api.DatabasePoolStats{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		}
+	}
+
+	status := http.StatusOK
+	switch {
+	case !requiredUp:
+		health.Status = api.HealthStatusDown
+		status = http.StatusServiceUnavailable
+	case !allUp:
+		health.Status = api.HealthStatusDegraded
+	}
+	c.JSON(status, health)
+}
+
+func (s *HRSystem) GetMetrics(c *gin.Context) {
+	promhttp.Handler().ServeHTTP(c.Writer, c.Request)
+}
+
+// checkDependencies pings all dependencies concurrently and reports whether the required ones,
+// and all of them, are up.
+func (s *HRSystem) checkDependencies(ctx context.Context) (checks map[string]api.DependencyCheck, requiredUp, allUp bool) {
+	results := make([]api.DependencyCheck, len(s.dependencies))
+	var wg sync.WaitGroup
+	for i, dep := range s.dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check(ctx, dep)
+		}()
+	}
+	wg.Wait()
+
+	checks = make(map[string]api.DependencyCheck, len(results))
+	requiredUp, allUp = true, true
+	for i, result := range results {
+		checks[s.dependencies[i].name] = result
+		if result.Status == api.DependencyCheckStatusDown {
+			allUp = false
+			if result.Required {
+				requiredUp = false
+			}
+		}
+	}
+	return checks, requiredUp, allUp
+}
+
+func check(ctx context.Context, dep dependency) api.DependencyCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dep.ping(ctx)
+	result := api.DependencyCheck{
+		Status:    api.DependencyCheckStatusUp,
+		Required:  dep.required,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		message := err.Error()
+		result.Status = api.DependencyCheckStatusDown
+		result.Error = &message
+	}
+	return result
+}
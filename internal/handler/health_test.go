@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/api"
+)
+
+func up(ctx context.Context) error { return nil }
+
+func down(ctx context.Context) error { return errors.New("connection refused") }
+
+func serveProbe(handle func(c *gin.Context)) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	handle(c)
+	return w
+}
+
+func TestGetReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := &HRSystem{dependencies: []dependency{
+		{name: "database", required: true, ping: up},
+		{name: "redis", ping: down},
+	}}
+
+	require.Equal(t, http.StatusServiceUnavailable, serveProbe(s.GetReadiness).Code)
+	s.SetReady(true)
+	w := serveProbe(s.GetReadiness)
+	require.Equal(t, http.StatusOK, w.Code, "optional dependencies do not affect readiness")
+	var resp api.Readiness
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, api.DependencyCheckStatusUp, resp.Checks["database"].Status)
+	require.Equal(t, api.DependencyCheckStatusDown, resp.Checks["redis"].Status)
+	require.Nil(t, resp.Checks["redis"].Error, "errors are only reported by the health report")
+
+	s.dependencies[0].ping = down
+	require.Equal(t, http.StatusServiceUnavailable, serveProbe(s.GetReadiness).Code)
+
+	// shutting down
+	s.dependencies[0].ping = up
+	s.SetReady(false)
+	require.Equal(t, http.StatusServiceUnavailable, serveProbe(s.GetReadiness).Code)
+}
+
+func TestGetHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:password@tcp(127.0.0.1:1)/hrs",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	s := &HRSystem{gdb: gdb, dependencies: newDependencies(gdb, nil)}
+
+	w := serveProbe(s.GetHealth)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var resp api.Health
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, api.HealthStatusDown, resp.Status)
+	require.Equal(t, api.DependencyCheckStatusDown, resp.Checks["database"].Status)
+	require.Equal(t, Version, resp.Version)
+
+	s.dependencies = []dependency{{name: "database", required: true, ping: up}, {name: "redis", ping: down}}
+	w = serveProbe(s.GetHealth)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, api.HealthStatusDegraded, resp.Status)
+}
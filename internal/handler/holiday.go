@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	openapitypes "github.com/oapi-codegen/runtime/types"
+
+	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/service"
+	"github.com/joremysh/fliqt/pkg/ical"
+)
+
+func (s *HRSystem) ListHolidays(c *gin.Context, params api.ListHolidaysParams) {
+	query := &model.HolidayQuery{
+		Page:     1,
+		PageSize: 50,
+		From:     dateStart(params.From),
+		To:       dateEnd(params.To),
+	}
+	if params.Page != nil {
+		query.Page = *params.Page
+	}
+	if params.PageSize != nil {
+		query.PageSize = *params.PageSize
+	}
+	if params.Region != nil {
+		query.Region = *params.Region
+	}
+
+	result, err := s.holidayService.ListHolidays(c.Request.Context(), query)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := &api.ListHolidaysResponse{
+		Data:       make([]api.Holiday, len(result.Data)),
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalCount: result.TotalCount,
+	}
+	for i, holiday := range result.Data {
+		resp.Data[i] = *ConvertToHolidayResponse(&holiday)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (s *HRSystem) CreateHoliday(c *gin.Context) {
+	var holiday api.Holiday
+	if err := c.Bind(&holiday); err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Holiday")
+		return
+	}
+
+	created, err := s.holidayService.CreateHoliday(c.Request.Context(), &model.Holiday{
+		Region: holiday.Region,
+		Date:   holiday.Date.Time,
+		Name:   holiday.Name,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, ConvertToHolidayResponse(created))
+}
+
+func (s *HRSystem) GetHoliday(c *gin.Context, id int64) {
+	holiday, err := s.holidayService.GetHoliday(c.Request.Context(), uint(id))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToHolidayResponse(holiday))
+}
+
+func (s *HRSystem) UpdateHoliday(c *gin.Context, id int64) {
+	var holiday api.Holiday
+	if err := c.Bind(&holiday); err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Holiday")
+		return
+	}
+
+	updated, err := s.holidayService.UpdateHoliday(c.Request.Context(), &model.Holiday{
+		ID:     uint(id),
+		Region: holiday.Region,
+		Date:   holiday.Date.Time,
+		Name:   holiday.Name,
+	})
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToHolidayResponse(updated))
+}
+
+func (s *HRSystem) DeleteHoliday(c *gin.Context, id int64) {
+	if err := s.holidayService.DeleteHoliday(c.Request.Context(), uint(id)); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *HRSystem) ImportHolidays(c *gin.Context, params api.ImportHolidaysParams) {
+	holidays, err := parseHolidayCalendar(c.Request.Body)
+	if err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Holiday Import: "+err.Error())
+		return
+	}
+
+	result, err := s.holidayService.ImportHolidays(c.Request.Context(), params.Region, holidays)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, api.HolidayImport{
+		Created:   result.Created,
+		Updated:   result.Updated,
+		Unchanged: result.Unchanged,
+	})
+}
+
+// parseHolidayCalendar returns a holiday for every day of every event of an iCalendar file,
+// without a region. Events without a summary are named "Holiday".
+func parseHolidayCalendar(r io.Reader) ([]model.Holiday, error) {
+	events, err := ical.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	var holidays []model.Holiday
+	for _, event := range events {
+		name := event.Summary
+		if name == "" {
+			name = "Holiday"
+		}
+		if runes := []rune(name); len(runes) > 255 {
+			name = string(runes[:255])
+		}
+		for _, date := range event.Dates() {
+			holidays = append(holidays, model.Holiday{Date: date, Name: name})
+			// the service refuses imports this large, there is no need to expand them further
+			if len(holidays) > service.MaxHolidayImport {
+				return holidays, nil
+			}
+		}
+	}
+	return holidays, nil
+}
+
+func ConvertToHolidayResponse(holiday *model.Holiday) *api.Holiday {
+	id := int64(holiday.ID)
+	return &api.Holiday{
+		Id:     &id,
+		Region: holiday.Region,
+		Date:   openapitypes.Date{Time: holiday.Date},
+		Name:   holiday.Name,
+	}
+}
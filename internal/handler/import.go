@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+
+	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/service"
+)
+
+// newEmployeeSchema is the schema addEmployee validates its body with, import rows are held to it
+// one by one so that a bad row does not fail the whole file.
+var newEmployeeSchema = sync.OnceValues(func() (*openapi3.Schema, error) {
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	ref, ok := swagger.Components.Schemas["NewEmployee"]
+	if !ok || ref.Value == nil {
+		return nil, errors.New("the spec has no NewEmployee schema")
+	}
+	return ref.Value, nil
+})
+
+func (s *HRSystem) ImportEmployees(c *gin.Context, params api.ImportEmployeesParams) {
+	schema, err := newEmployeeSchema()
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	var rows []service.ImportRow
+	if c.ContentType() == "text/csv" {
+		rows, err = parseImportCSV(c.Request.Body, schema)
+	} else {
+		rows, err = parseImportJSON(c.Request.Body, schema)
+	}
+	if err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Employee Import: "+err.Error())
+		return
+	}
+
+	dryRun := params.DryRun != nil && *params.DryRun
+	employeeImport, err := s.employeeImportService.ImportEmployees(c.Request.Context(), rows, dryRun)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp, err := ConvertToEmployeeImportResponse(employeeImport)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	if employeeImport.Status == model.ImportStatusRunning {
+		c.Header("Location", fmt.Sprintf("/employee-imports/%d", employeeImport.ID))
+		c.JSON(http.StatusAccepted, resp)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (s *HRSystem) GetEmployeeImport(c *gin.Context, id int64) {
+	employeeImport, err := s.employeeImportService.GetImport(c.Request.Context(), uint(id))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp, err := ConvertToEmployeeImportResponse(employeeImport)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Shutdown stops the imports running in the background after their current batch, and waits
+// for them to record how far they got until ctx is done.
+func (s *HRSystem) Shutdown(ctx context.Context) error {
+	return s.employeeImportService.Shutdown(ctx)
+}
+
+func ConvertToEmployeeImportResponse(employeeImport *model.EmployeeImport) (*api.EmployeeImport, error) {
+	resp := &api.EmployeeImport{
+		Id:            int64(employeeImport.ID),
+		Status:        api.EmployeeImportStatus(employeeImport.Status),
+		DryRun:        employeeImport.DryRun,
+		TotalRows:     employeeImport.TotalRows,
+		ProcessedRows: employeeImport.ProcessedRows,
+		ImportedRows:  employeeImport.ImportedRows,
+		FailedRows:    employeeImport.FailedRows,
+		Errors:        []api.ImportRowError{},
+		CreatedAt:     employeeImport.CreatedAt,
+		FinishedAt:    employeeImport.FinishedAt,
+	}
+	if employeeImport.Error != "" {
+		resp.Error = &employeeImport.Error
+	}
+	if employeeImport.Errors != "" {
+		if err := json.Unmarshal([]byte(employeeImport.Errors), &resp.Errors); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// parseImportJSON reads a JSON array of objects.
+func parseImportJSON(r io.Reader, schema *openapi3.Schema) ([]service.ImportRow, error) {
+	var objects []map[string]any
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, err
+	}
+	rows := make([]service.ImportRow, len(objects))
+	for i, object := range objects {
+		rows[i] = parseImportRow(object, schema)
+	}
+	return rows, nil
+}
+
+// parseImportCSV reads a CSV file whose header names the NewEmployee property of every column.
+// Empty cells are left out, and numeric columns are converted so that the rows can be validated
+// like JSON objects.
+func parseImportCSV(r io.Reader, schema *openapi3.Schema) ([]service.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the header row is missing")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		if _, ok := schema.Properties[column]; !ok {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+		header[i] = column
+	}
+
+	var rows []service.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) > len(header) {
+			rows = append(rows, service.ImportRow{Errors: []service.ImportRowError{{
+				Code:    codeInvalidRequest,
+				Message: fmt.Sprintf("the row has %d fields, the header %d", len(record), len(header)),
+			}}})
+			continue
+		}
+		object := make(map[string]any, len(header))
+		for i, value := range record {
+			if value == "" {
+				continue
+			}
+			object[header[i]] = value
+			if property := schema.Properties[header[i]].Value; property != nil && property.Type.Includes(openapi3.TypeInteger) {
+				if number, err := strconv.ParseFloat(value, 64); err == nil {
+					object[header[i]] = number
+				}
+			}
+		}
+		rows = append(rows, parseImportRow(object, schema))
+	}
+}
+
+// parseImportRow validates an object against the NewEmployee schema and converts it. The row errors
+// name the property they refer to.
+func parseImportRow(object map[string]any, schema *openapi3.Schema) service.ImportRow {
+	var row service.ImportRow
+	if err := schema.VisitJSON(object, openapi3.MultiErrors()); err != nil {
+		var multiErr openapi3.MultiError
+		if !errors.As(err, &multiErr) {
+			multiErr = openapi3.MultiError{err}
+		}
+		for _, err := range multiErr {
+			rowErr := service.ImportRowError{Code: codeInvalidRequest, Message: err.Error()}
+			var schemaErr *openapi3.SchemaError
+			if errors.As(err, &schemaErr) {
+				rowErr.Message = schemaErr.Reason
+				if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+					rowErr.Field = pointer[0]
+				}
+			}
+			row.Errors = append(row.Errors, rowErr)
+		}
+		return row
+	}
+
+	// the schema leaves a few formats, such as email, to decoding
+	var newEmployee api.NewEmployee
+	encoded, err := json.Marshal(object)
+	if err == nil {
+		err = json.Unmarshal(encoded, &newEmployee)
+	}
+	if err != nil {
+		row.Errors = append(row.Errors, service.ImportRowError{Code: codeInvalidRequest, Message: err.Error()})
+		return row
+	}
+	row.Employee = &model.Employee{
+		Name:        newEmployee.Name,
+		Email:       string(newEmployee.Email),
+		PhoneNumber: newEmployee.PhoneNumber,
+		Department:  string(newEmployee.Department),
+		Address:     newEmployee.Address,
+		Salary:      newEmployee.Salary,
+		OnboardDate: newEmployee.OnboardDate.Time,
+		Title:       newEmployee.Title,
+		Level:       newEmployee.Level,
+		ManagerID:   parseManagerID(newEmployee.ManagerID),
+		Region:      parseRegion(newEmployee.Region),
+	}
+	return row
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/internal/service"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	schema, err := newEmployeeSchema()
+	require.NoError(t, err)
+
+	csv := "\ufeffname,email,phoneNumber,address,title,level,salary,onboardDate,department,managerID\n" +
+		"Ada,ada@example.com,0912,Taipei,Engineer,Senior,90000,2024-03-01,Engineering,7\n" +
+		"Bob,bob@example.com,0913,Taipei,Engineer,Mid,lots,2024-03-01,Marketing,\n" +
+		"Cy,not-an-email,0914,Taipei,Engineer,Mid,50000,2024-03-01,Sales,\n" +
+		"Di,di@example.com,0915,Taipei,Engineer,Mid,50000,2024-03-01,Sales,,extra\n" +
+		",ed@example.com,0916,Taipei,Engineer,Mid,50000,2024-03-01,Sales,\n"
+	rows, err := parseImportCSV(strings.NewReader(csv), schema)
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	require.Empty(t, rows[0].Errors)
+	employee := rows[0].Employee
+	require.Equal(t, "ada@example.com", employee.Email)
+	require.Equal(t, 90000, employee.Salary)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), employee.OnboardDate)
+	require.Equal(t, uint(7), *employee.ManagerID)
+
+	require.Nil(t, rows[1].Employee)
+	require.ElementsMatch(t, []string{"salary", "department"}, errorFields(rows[1].Errors))
+
+	require.Nil(t, rows[2].Employee)
+	require.Len(t, rows[2].Errors, 1)
+
+	require.Nil(t, rows[3].Employee)
+	require.Len(t, rows[3].Errors, 1)
+
+	require.Nil(t, rows[4].Employee)
+	require.Equal(t, []string{"name"}, errorFields(rows[4].Errors))
+}
+
+func TestParseImportCSV_UnknownColumn(t *testing.T) {
+	schema, err := newEmployeeSchema()
+	require.NoError(t, err)
+
+	_, err = parseImportCSV(strings.NewReader("name,mail\nAda,ada@example.com\n"), schema)
+	require.ErrorContains(t, err, `unknown column "mail"`)
+
+	_, err = parseImportCSV(strings.NewReader(""), schema)
+	require.Error(t, err)
+}
+
+func TestParseImportJSON(t *testing.T) {
+	schema, err := newEmployeeSchema()
+	require.NoError(t, err)
+
+	rows, err := parseImportJSON(strings.NewReader(`[
+		{"name":"Ada","email":"ada@example.com","phoneNumber":"0912","address":"Taipei","title":"Engineer",
+		 "level":"Senior","salary":90000,"onboardDate":"2024-03-01","department":"Engineering"},
+		{"name":"Bob","email":"bob@example.com","salary":-1}
+	]`), schema)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.Empty(t, rows[0].Errors)
+	require.Nil(t, rows[0].Employee.ManagerID)
+	require.Contains(t, errorFields(rows[1].Errors), "salary")
+	require.Contains(t, errorFields(rows[1].Errors), "onboardDate")
+}
+
+func errorFields(rowErrors []service.ImportRowError) []string {
+	fields := make([]string, len(rowErrors))
+	for i, rowErr := range rowErrors {
+		fields[i] = rowErr.Field
+	}
+	return fields
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+func (s *HRSystem) ListLeaveTypes(c *gin.Context, params api.ListLeaveTypesParams) {
+	inactive := params.IncludeInactive != nil && *params.IncludeInactive
+	leaveTypes, err := s.leaveTypeService.ListLeaveTypes(c.Request.Context(), inactive)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	resp := &api.ListLeaveTypesResponse{Data: make([]api.LeaveType, len(leaveTypes))}
+	for i, leaveType := range leaveTypes {
+		resp.Data[i] = *ConvertToLeaveTypeResponse(&leaveType)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (s *HRSystem) CreateLeaveType(c *gin.Context) {
+	var leaveType api.LeaveType
+	if err := c.Bind(&leaveType); err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Leave Type")
+		return
+	}
+
+	created, err := s.leaveTypeService.CreateLeaveType(c.Request.Context(), parseLeaveType(&leaveType))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, ConvertToLeaveTypeResponse(created))
+}
+
+func (s *HRSystem) GetLeaveType(c *gin.Context, id int64) {
+	leaveType, err := s.leaveTypeService.GetLeaveType(c.Request.Context(), uint(id))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToLeaveTypeResponse(leaveType))
+}
+
+func (s *HRSystem) UpdateLeaveType(c *gin.Context, id int64) {
+	var leaveType api.LeaveType
+	if err := c.Bind(&leaveType); err != nil {
+		sendErrorResponse(c, http.StatusBadRequest, codeInvalidRequest, "Invalid format for Leave Type")
+		return
+	}
+
+	req := parseLeaveType(&leaveType)
+	req.ID = uint(id)
+	updated, err := s.leaveTypeService.UpdateLeaveType(c.Request.Context(), req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ConvertToLeaveTypeResponse(updated))
+}
+
+func (s *HRSystem) DeleteLeaveType(c *gin.Context, id int64) {
+	if err := s.leaveTypeService.DeleteLeaveType(c.Request.Context(), uint(id)); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// parseLeaveType converts a leave type of a request, applying the defaults of the optional fields.
+func parseLeaveType(leaveType *api.LeaveType) *model.LeaveType {
+	result := &model.LeaveType{
+		Code:               leaveType.Code,
+		Name:               leaveType.Name,
+		Paid:               leaveType.Paid,
+		RequiresAttachment: leaveType.RequiresAttachment,
+		RequiresApproval:   leaveType.RequiresApproval,
+		Entitlement:        string(leaveType.Entitlement),
+		Active:             true,
+	}
+	if leaveType.MaxConsecutiveDays != nil {
+		result.MaxConsecutiveDays = *leaveType.MaxConsecutiveDays
+	}
+	if leaveType.MinNoticeDays != nil {
+		result.MinNoticeDays = *leaveType.MinNoticeDays
+	}
+	if leaveType.MinServiceMonths != nil {
+		result.MinServiceMonths = *leaveType.MinServiceMonths
+	}
+	if leaveType.AnnualDays != nil {
+		result.AnnualDays = *leaveType.AnnualDays
+	}
+	if leaveType.Active != nil {
+		result.Active = *leaveType.Active
+	}
+	return result
+}
+
+func ConvertToLeaveTypeResponse(leaveType *model.LeaveType) *api.LeaveType {
+	id := int64(leaveType.ID)
+	return &api.LeaveType{
+		Id:                 &id,
+		Code:               leaveType.Code,
+		Name:               leaveType.Name,
+		Paid:               leaveType.Paid,
+		RequiresAttachment: leaveType.RequiresAttachment,
+		RequiresApproval:   leaveType.RequiresApproval,
+		MaxConsecutiveDays: &leaveType.MaxConsecutiveDays,
+		MinNoticeDays:      &leaveType.MinNoticeDays,
+		MinServiceMonths:   &leaveType.MinServiceMonths,
+		Entitlement:        api.LeaveTypeEntitlement(leaveType.Entitlement),
+		AnnualDays:         &leaveType.AnnualDays,
+		Active:             &leaveType.Active,
+	}
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(t *testing.T, buf *bytes.Buffer) *gin.Engine {
+	loggers, err := New(Options{Output: buf, Level: "info"})
+	require.NoError(t, err)
+	logger := loggers.For(ComponentHTTP)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(NewRequestIDMiddleware(), NewAccessLogMiddleware(logger), NewRecoveryMiddleware(logger))
+	r.GET("/employees/:id", func(c *gin.Context) {
+		loggers.For(ComponentApp).InfoContext(c.Request.Context(), "handling")
+		c.Status(http.StatusOK)
+	})
+	r.GET("/failure", func(c *gin.Context) {
+		_ = c.Error(errors.New("database down"))
+		c.Status(http.StatusInternalServerError)
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return r
+}
+
+func TestRequestIDMiddleware_HonoursHeader(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestRouter(t, &buf)
+
+	req := httptest.NewRequest(http.MethodGet, "/employees/1?email=someone@example.com", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, "abc-123", w.Header().Get(RequestIDHeader))
+	lines := records(t, &buf)
+	require.Len(t, lines, 2)
+	require.Equal(t, "handling", lines[0]["msg"])
+	require.Equal(t, "abc-123", lines[0]["request_id"])
+	require.Equal(t, "request", lines[1]["msg"])
+	require.Equal(t, "abc-123", lines[1]["request_id"])
+	require.Equal(t, "/employees/:id", lines[1]["route"])
+	require.Equal(t, "/employees/1", lines[1]["path"])
+	require.EqualValues(t, http.StatusOK, lines[1]["status"])
+	require.NotContains(t, buf.String(), "someone@example.com")
+}
+
+func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
+	for _, header := range []string{"", "forged\nline", string(make([]byte, 200))} {
+		var buf bytes.Buffer
+		r := newTestRouter(t, &buf)
+
+		req := httptest.NewRequest(http.MethodGet, "/employees/1", nil)
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		id := w.Header().Get(RequestIDHeader)
+		require.Len(t, id, 32)
+		require.Equal(t, id, records(t, &buf)[0]["request_id"])
+	}
+}
+
+func TestAccessLogMiddleware_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestRouter(t, &buf)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/failure", nil))
+	lines := records(t, &buf)
+	require.Len(t, lines, 1)
+	require.Equal(t, "ERROR", lines[0]["level"])
+	require.Equal(t, "database down", lines[0]["error"])
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestRouter(t, &buf)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	lines := records(t, &buf)
+	require.Len(t, lines, 2)
+	require.Equal(t, "panic recovered", lines[0]["msg"])
+	require.Equal(t, "boom", lines[0]["panic"])
+	require.NotEmpty(t, lines[0]["request_id"])
+	require.EqualValues(t, http.StatusInternalServerError, lines[1]["status"])
+}
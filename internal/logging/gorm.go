@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger logs GORM statements: failures as errors, slow statements as warnings and
+// everything else at debug level. Levels are those of the logger, GORM's own are ignored.
+type GormLogger struct {
+	logger        *slog.Logger
+	slowThreshold time.Duration
+	redact        bool
+}
+
+// NewGormLogger returns a GORM logger. With redact set, statements are logged with their
+// placeholders instead of the argument values, which include salaries and personal data.
+func NewGormLogger(logger *slog.Logger, slowThreshold time.Duration, redact bool) *GormLogger {
+	return &GormLogger{logger: logger, slowThreshold: slowThreshold, redact: redact}
+}
+
+func (l *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...any) {
+	l.logger.InfoContext(ctx, fmt.Sprintf(msg, data...))
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...any) {
+	l.logger.WarnContext(ctx, fmt.Sprintf(msg, data...))
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...any) {
+	l.logger.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	var (
+		level = slog.LevelDebug
+		msg   = "query"
+	)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level, msg = slog.LevelError, "query failed"
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold:
+		level, msg = slog.LevelWarn, "slow query"
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("elapsed_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if level == slog.LevelError {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// ParamsFilter implements gorm.ParamsFilter; dropping the arguments leaves the placeholders in the logged SQL.
+func (l *GormLogger) ParamsFilter(_ context.Context, sql string, params ...any) (string, []any) {
+	if l.redact {
+		return sql, nil
+	}
+	return sql, params
+}
@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGormLogger_RedactsParameters(t *testing.T) {
+	const sql = "UPDATE `employees` SET `salary`=? WHERE `id` = ?"
+	params := []any{125000, 7}
+
+	redacted, vars := NewGormLogger(nil, 0, true).ParamsFilter(context.Background(), sql, params...)
+	require.Equal(t, sql, redacted)
+	require.Empty(t, vars)
+	require.Equal(t, sql, logger.ExplainSQL(redacted, nil, `'`, vars...))
+
+	_, vars = NewGormLogger(nil, 0, false).ParamsFilter(context.Background(), sql, params...)
+	require.Equal(t, params, vars)
+}
+
+func TestGormLogger_Trace(t *testing.T) {
+	var buf bytes.Buffer
+	loggers, err := New(Options{Output: &buf, Level: "warn"})
+	require.NoError(t, err)
+	gormLogger := NewGormLogger(loggers.For(ComponentSQL), 100*time.Millisecond, true)
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	fc := func() (string, int64) { return "SELECT * FROM `employees` WHERE `id` = ?", 1 }
+	gormLogger.Trace(ctx, time.Now(), fc, nil)
+	gormLogger.Trace(ctx, time.Now(), fc, gorm.ErrRecordNotFound)
+	gormLogger.Trace(ctx, time.Now().Add(-time.Second), fc, nil)
+	gormLogger.Trace(ctx, time.Now(), fc, context.DeadlineExceeded)
+
+	lines := records(t, &buf)
+	require.Len(t, lines, 2)
+	require.Equal(t, "slow query", lines[0]["msg"])
+	require.Equal(t, "req-1", lines[0]["request_id"])
+	require.Equal(t, "query failed", lines[1]["msg"])
+	require.Equal(t, context.DeadlineExceeded.Error(), lines[1]["error"])
+	require.Equal(t, "SELECT * FROM `employees` WHERE `id` = ?", lines[1]["sql"])
+}
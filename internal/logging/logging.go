@@ -0,0 +1,119 @@
+// Package logging provides the JSON loggers of the server. Every component gets its own
+// logger and level, and records logged with a request context carry its request and trace IDs.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Components that can be given their own level.
+const (
+	ComponentApp   = "app"
+	ComponentHTTP  = "http"
+	ComponentSQL   = "sql"
+	ComponentCache = "cache"
+)
+
+var Components = []string{ComponentApp, ComponentHTTP, ComponentSQL, ComponentCache}
+
+// Options configures the loggers. Level applies to every component without an entry in Levels.
+type Options struct {
+	Output io.Writer
+	Level  string
+	Levels map[string]string
+}
+
+type Loggers struct {
+	handler slog.Handler
+	level   slog.Level
+	levels  map[string]slog.Level
+}
+
+func New(options Options) (*Loggers, error) {
+	level, err := ParseLevel(options.Level)
+	if err != nil {
+		return nil, err
+	}
+	levels := make(map[string]slog.Level, len(options.Levels))
+	for component, raw := range options.Levels {
+		if levels[component], err = ParseLevel(raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", component, err)
+		}
+	}
+	// the JSON handler lets everything through, the component loggers do the filtering
+	handler := slog.NewJSONHandler(options.Output, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return &Loggers{handler: contextHandler{handler}, level: level, levels: levels}, nil
+}
+
+// For returns the logger of a component.
+func (l *Loggers) For(component string) *slog.Logger {
+	level, ok := l.levels[component]
+	if !ok {
+		level = l.level
+	}
+	return slog.New(levelHandler{level: level, next: l.handler}).With(slog.String("component", component))
+}
+
+// ParseLevel accepts debug, info, warn and error.
+func ParseLevel(raw string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(raw)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", raw)
+	}
+	return level, nil
+}
+
+type levelHandler struct {
+	level slog.Level
+	next  slog.Handler
+}
+
+func (h levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.next.Handle(ctx, record)
+}
+
+func (h levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return levelHandler{level: h.level, next: h.next.WithAttrs(attrs)}
+}
+
+func (h levelHandler) WithGroup(name string) slog.Handler {
+	return levelHandler{level: h.level, next: h.next.WithGroup(name)}
+}
+
+// contextHandler adds the request ID and the current span to records logged with a context.
+type contextHandler struct {
+	next slog.Handler
+}
+
+func (h contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if id := RequestIDFromContext(ctx); id != "" {
+			record.AddAttrs(slog.String("request_id", id))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+		}
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.next.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.next.WithGroup(name)}
+}
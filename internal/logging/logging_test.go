@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// records decodes the JSON lines written to buf.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		lines = append(lines, record)
+	}
+	return lines
+}
+
+func TestLoggers_ComponentLevels(t *testing.T) {
+	var buf bytes.Buffer
+	loggers, err := New(Options{Output: &buf, Level: "info", Levels: map[string]string{ComponentSQL: "warn"}})
+	require.NoError(t, err)
+
+	loggers.For(ComponentApp).Info("app info")
+	loggers.For(ComponentApp).Debug("app debug")
+	loggers.For(ComponentSQL).Info("sql info")
+	loggers.For(ComponentSQL).Warn("sql warn")
+
+	lines := records(t, &buf)
+	require.Len(t, lines, 2)
+	require.Equal(t, "app info", lines[0]["msg"])
+	require.Equal(t, "app", lines[0]["component"])
+	require.Equal(t, "sql warn", lines[1]["msg"])
+	require.Equal(t, "sql", lines[1]["component"])
+}
+
+func TestLoggers_RequestIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	loggers, err := New(Options{Output: &buf, Level: "info"})
+	require.NoError(t, err)
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	loggers.For(ComponentApp).With("key", "value").InfoContext(ctx, "with request")
+	loggers.For(ComponentApp).Info("without request")
+
+	lines := records(t, &buf)
+	require.Len(t, lines, 2)
+	require.Equal(t, "req-1", lines[0]["request_id"])
+	require.Equal(t, "value", lines[0]["key"])
+	require.NotContains(t, lines[1], "request_id")
+}
+
+func TestNew_RejectsUnknownLevels(t *testing.T) {
+	_, err := New(Options{Output: &bytes.Buffer{}, Level: "verbose"})
+	require.Error(t, err)
+	_, err = New(Options{Output: &bytes.Buffer{}, Level: "info", Levels: map[string]string{ComponentSQL: "trace"}})
+	require.ErrorContains(t, err, "sql")
+}
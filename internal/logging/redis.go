@@ -0,0 +1,16 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// RedisLogger routes the go-redis internal log, such as failed reconnects, to a logger.
+type RedisLogger struct {
+	Logger *slog.Logger
+}
+
+func (l RedisLogger) Printf(ctx context.Context, format string, v ...any) {
+	l.Logger.WarnContext(ctx, fmt.Sprintf(format, v...))
+}
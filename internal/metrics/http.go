@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/joremysh/fliqt/internal/routes"
+)
+
+// unmatchedOperation labels requests that do not match any route, so that scanners
+// probing random paths cannot blow up the number of series.
+const unmatchedOperation = "unmatched"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by OpenAPI operation and status code.",
+	}, []string{"operation", "method", "status"})
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by OpenAPI operation and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "method", "status"})
+)
+
+// NewHTTPMiddleware records the count and latency of every request. It has to run before
+// the request validator so that rejected requests are counted too.
+func NewHTTPMiddleware(swagger *openapi3.T) gin.HandlerFunc {
+	operations := routes.NewOperations(swagger)
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		method := c.Request.Method
+		operation, ok := operations.Lookup(c)
+		if !ok {
+			operation, method = unmatchedOperation, ""
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		httpRequests.WithLabelValues(operation, method, status).Inc()
+		httpRequestDuration.WithLabelValues(operation, method, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/api"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	swagger, err := api.GetSwagger()
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(NewHTTPMiddleware(swagger))
+	r.GET("/employees/:id", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("findEmployeeByID", http.MethodGet, "404"))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/employees/42", nil))
+	require.Equal(t, before+1, testutil.ToFloat64(httpRequests.WithLabelValues("findEmployeeByID", http.MethodGet, "404")))
+
+	before = testutil.ToFloat64(httpRequests.WithLabelValues(unmatchedOperation, "", "404"))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wp-admin", nil))
+	require.Equal(t, before+1, testutil.ToFloat64(httpRequests.WithLabelValues(unmatchedOperation, "", "404")))
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	AuditEntityEmployee  = "employee"
+	AuditEntityDayOff    = "day_off"
+	AuditEntityHoliday   = "holiday"
+	AuditEntityLeaveType = "leave_type"
+)
+
+const (
+	AuditOperationCreate    = "create"
+	AuditOperationUpdate    = "update"
+	AuditOperationDelete    = "delete"
+	AuditOperationCancel    = "cancel"
+	AuditOperationApprove   = "approve"
+	AuditOperationReject    = "reject"
+	AuditOperationTerminate = "terminate"
+	AuditOperationRehire    = "rehire"
+)
+
+// AuditEvent records a single mutation. Events are only ever inserted, never updated or deleted.
+type AuditEvent struct {
+	ID         uint      `gorm:"primarykey"`
+	OccurredAt time.Time `gorm:"not null;index"`
+	ActorID    *uint     `gorm:"index"` // nil for internal callers such as seeds
+	ActorRole  string    `gorm:"type:varchar(20)"`
+	EntityType string    `gorm:"type:varchar(50);not null;index:idx_audit_entity"`
+	EntityID   uint      `gorm:"not null;index:idx_audit_entity"`
+	Operation  string    `gorm:"type:varchar(20);not null"`
+	Changes    string    `gorm:"type:json"` // JSON object of field name to {"before", "after"}
+}
+
+type AuditEventQuery struct {
+	Page       int
+	PageSize   int
+	EntityType string
+	EntityID   *uint
+	ActorID    *uint
+	From       *time.Time
+	To         *time.Time
+}
@@ -6,12 +6,55 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	DayOffStatusPending   = "pending"
+	DayOffStatusApproved  = "approved"
+	DayOffStatusRejected  = "rejected"
+	DayOffStatusCancelled = "cancelled"
+	DayOffStatusWithdrawn = "withdrawn"
+)
+
+// ActiveDayOffStatuses are the statuses of records that still hold the requested time slot.
+var ActiveDayOffStatuses = []string{DayOffStatusPending, DayOffStatusApproved}
+
 type DayOffRecord struct {
 	gorm.Model
 	EmployeeID uint
 	Employee   Employee `gorm:"foreignKey:EmployeeID"`
 	DayOffType string   `gorm:"type:varchar(50)"`
 	Reason     string
-	StartTime  time.Time `gorm:"index"`
-	EndTime    time.Time
+	// AttachmentURL links a supporting document, e.g. a medical certificate.
+	AttachmentURL string    `gorm:"type:varchar(2048);not null"`
+	StartTime     time.Time `gorm:"index"`
+	EndTime       time.Time
+	// HalfDay is AM or PM for requests of half a working day, and empty otherwise.
+	HalfDay string `gorm:"type:varchar(2)"`
+	// DurationHours and DurationDays are the working time of the request, computed on submission.
+	DurationHours float64 `gorm:"type:decimal(8,2);not null;default:0"`
+	DurationDays  float64 `gorm:"type:decimal(9,4);not null;default:0"`
+	Status        string  `gorm:"type:varchar(20);not null;default:pending;index"`
+	ReviewerID    *uint
+	ReviewedAt    *time.Time
+	ReviewComment string `gorm:"type:varchar(255)"`
+	// CancelledAt and CancelledBy are set when the request is withdrawn or cancelled.
+	CancelledAt        *time.Time
+	CancelledBy        *uint
+	CancellationReason string `gorm:"type:varchar(255)"`
+}
+
+// DayOffQuery selects the day off records of an employee. The times bound half-open ranges:
+// From and To select the records overlapping [From, To), StartFrom and StartTo the records
+// starting in [StartFrom, StartTo).
+type DayOffQuery struct {
+	Page       int
+	PageSize   int
+	SortBy     string
+	SortOrder  string // "asc" or "desc"
+	EmployeeID uint
+	From       *time.Time
+	To         *time.Time
+	StartFrom  *time.Time
+	StartTo    *time.Time
+	DayOffType string
+	Status     string
 }
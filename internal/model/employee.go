@@ -4,6 +4,11 @@ import (
 	"time"
 )
 
+const (
+	EmploymentStatusActive     = "active"
+	EmploymentStatusTerminated = "terminated"
+)
+
 type Employee struct {
 	ID          uint      `gorm:"primarykey"`
 	Name        string    `gorm:"type:varchar(50);not null;index"`
@@ -13,8 +18,16 @@ type Employee struct {
 	Title       string    `gorm:"type:varchar(50);not null"`
 	Level       string    `gorm:"type:varchar(50);not null"`
 	Address     string    `gorm:"type:varchar(255);not null"`
-	Salary      int       `gorm:"type:mediumint unsigned;not null"` // Assuming NTD is used here, if decimal points need to be stored, it can be switched to `decimal` or other methods.
+	Salary      int       `gorm:"type:mediumint unsigned;not null"` // Monthly, assuming NTD is used here, if decimal points need to be stored, it can be switched to `decimal` or other methods.
 	OnboardDate time.Time `gorm:"not null"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// Region is where the employee works, the holidays of the region are not worked.
+	Region    string    `gorm:"type:varchar(10);not null;default:''"`
+	ManagerID *uint     `gorm:"index"`
+	Manager   *Employee `gorm:"foreignKey:ManagerID;constraint:OnDelete:SET NULL"`
+	// Employees are never deleted, leaving the company is recorded as a termination.
+	EmploymentStatus  string `gorm:"type:varchar(20);not null;default:active;index"`
+	TerminationDate   *time.Time
+	TerminationReason string `gorm:"type:varchar(255)"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
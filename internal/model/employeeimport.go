@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	ImportStatusRunning   = "running"
+	ImportStatusSucceeded = "succeeded"
+	ImportStatusFailed    = "failed"
+)
+
+// EmployeeImport tracks a bulk import of employees, so that the progress of imports running in
+// the background can be polled from any instance.
+type EmployeeImport struct {
+	ID            uint   `gorm:"primarykey"`
+	Status        string `gorm:"type:varchar(20);not null"`
+	DryRun        bool   `gorm:"not null"`
+	TotalRows     int    `gorm:"not null"`
+	ProcessedRows int    `gorm:"not null"`
+	ImportedRows  int    `gorm:"not null"`
+	FailedRows    int    `gorm:"not null"`
+	Errors        string `gorm:"type:json"` // JSON array of the row errors
+	Error         string `gorm:"type:varchar(255)"`
+	CreatedBy     *uint
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	FinishedAt    *time.Time
+}
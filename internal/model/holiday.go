@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+)
+
+// Holiday is a public holiday of a region, a day off for every employee working there. Regions
+// are ISO 3166 country or subdivision codes, e.g. TW or US-CA.
+type Holiday struct {
+	ID        uint      `gorm:"primarykey"`
+	Region    string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_holidays_region_date"`
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_holidays_region_date"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HolidayQuery selects holidays by region and by date, in [From, To).
+type HolidayQuery struct {
+	Page     int
+	PageSize int
+	Region   string
+	From     *time.Time
+	To       *time.Time
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	LeaveEntryAccrual = "accrual"
+	LeaveEntryDebit   = "debit"
+	LeaveEntryCredit  = "credit"
+)
+
+// LeaveLedgerEntry is an append-only movement on an employee's leave balance.
+// Accruals and credits are positive amounts, debits are negative.
+type LeaveLedgerEntry struct {
+	ID             uint     `gorm:"primarykey"`
+	EmployeeID     uint     `gorm:"not null;index:idx_leave_ledger_balance"`
+	Employee       Employee `gorm:"foreignKey:EmployeeID"`
+	Year           int      `gorm:"not null;index:idx_leave_ledger_balance"`
+	DayOffType     string   `gorm:"type:varchar(50);not null;index:idx_leave_ledger_balance"`
+	Kind           string   `gorm:"type:varchar(20);not null"`
+	Days           float64  `gorm:"type:decimal(9,4);not null"`
+	DayOffRecordID *uint    `gorm:"index"`
+	Note           string   `gorm:"type:varchar(255)"`
+	CreatedAt      time.Time
+}
+
+// LeaveBalance is the aggregated state of the ledger for one day off type in one year.
+type LeaveBalance struct {
+	DayOffType string
+	Year       int
+	Entitled   float64
+	Used       float64
+	Remaining  float64
+}
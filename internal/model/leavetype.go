@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	// LeaveEntitlementSeniority grants PTO days by years of service and level.
+	LeaveEntitlementSeniority = "seniority"
+	// LeaveEntitlementFlat grants AnnualDays a year, pro-rated in the onboarding year.
+	LeaveEntitlementFlat = "flat"
+)
+
+// LeaveType is a kind of day off employees can request, with the rules requests of it follow.
+// Day off records and leave ledger entries refer to it by Code.
+type LeaveType struct {
+	ID                 uint   `gorm:"primarykey"`
+	Code               string `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Name               string `gorm:"type:varchar(100);not null"`
+	Paid               bool   `gorm:"not null"`
+	RequiresAttachment bool   `gorm:"not null"`
+	// RequiresApproval is false for leave that is approved as soon as it is submitted.
+	RequiresApproval bool `gorm:"not null"`
+	// MaxConsecutiveDays bounds the working days of a single request, 0 for no bound.
+	MaxConsecutiveDays int `gorm:"not null"`
+	// MinNoticeDays is how many days ahead of its first day leave must be requested.
+	MinNoticeDays int `gorm:"not null"`
+	// MinServiceMonths is the service an employee needs to take the leave, e.g. their probation.
+	MinServiceMonths int     `gorm:"not null"`
+	Entitlement      string  `gorm:"type:varchar(20);not null"`
+	AnnualDays       float64 `gorm:"type:decimal(6,2);not null"`
+	// Active is false for types that are kept for the records of them but can no longer be requested.
+	Active    bool `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
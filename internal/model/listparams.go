@@ -6,4 +6,6 @@ type ListParams struct {
 	SortBy    string
 	SortOrder string // "asc" or "desc"
 	Filters   map[string]string
+	// IncludeTerminated also lists employees who left the company.
+	IncludeTerminated bool
 }
@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/joremysh/fliqt/internal/auth"
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+// Relationship describes how the caller relates to the employee being read.
+type Relationship string
+
+const (
+	RelationshipSelf    Relationship = "self"
+	RelationshipManager Relationship = "manager"
+	RelationshipHR      Relationship = "hr"
+	RelationshipOther   Relationship = "other"
+)
+
+// Field is a sensitive employee attribute subject to redaction.
+type Field string
+
+const (
+	FieldSalary            Field = "salary"
+	FieldAddress           Field = "address"
+	FieldPhoneNumber       Field = "phoneNumber"
+	FieldTerminationReason Field = "terminationReason"
+)
+
+type FieldSet map[Field]bool
+
+var allFields = FieldSet{FieldSalary: true, FieldAddress: true, FieldPhoneNumber: true, FieldTerminationReason: true}
+
+// visibleFields lists the sensitive fields each relationship may read. Non-sensitive fields
+// such as name, email and department are visible to every authenticated caller.
+var visibleFields = map[Relationship]FieldSet{
+	RelationshipSelf:    allFields,
+	RelationshipHR:      allFields,
+	RelationshipManager: {FieldSalary: true, FieldPhoneNumber: true},
+	RelationshipOther:   {},
+}
+
+// RelationshipOf resolves the relationship of the caller to the employee. Only the direct
+// manager is considered a manager.
+func RelationshipOf(principal *auth.Principal, employee *model.Employee) Relationship {
+	switch {
+	case principal.IsHRAdmin():
+		return RelationshipHR
+	case principal.EmployeeID == employee.ID:
+		return RelationshipSelf
+	case employee.ManagerID != nil && *employee.ManagerID == principal.EmployeeID:
+		return RelationshipManager
+	}
+	return RelationshipOther
+}
+
+// VisibleFields returns the sensitive fields of the employee the caller of ctx may read.
+// Internal callers without a principal see everything.
+func VisibleFields(ctx context.Context, employee *model.Employee) FieldSet {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return allFields
+	}
+	return visibleFields[RelationshipOf(principal, employee)]
+}
@@ -0,0 +1,36 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/internal/auth"
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+func TestVisibleFields(t *testing.T) {
+	managerID := uint(2)
+	employee := &model.Employee{ID: 1, ManagerID: &managerID}
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      FieldSet
+	}{
+		{"self", &auth.Principal{EmployeeID: 1, Role: auth.RoleEmployee}, allFields},
+		{"hr admin", &auth.Principal{EmployeeID: 9, Role: auth.RoleHRAdmin}, allFields},
+		{"direct manager", &auth.Principal{EmployeeID: 2, Role: auth.RoleManager}, FieldSet{FieldSalary: true, FieldPhoneNumber: true}},
+		{"other manager", &auth.Principal{EmployeeID: 3, Role: auth.RoleManager}, FieldSet{}},
+		{"colleague", &auth.Principal{EmployeeID: 4, Role: auth.RoleEmployee}, FieldSet{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.WithPrincipal(context.Background(), tt.principal)
+			require.Equal(t, tt.want, VisibleFields(ctx, employee))
+		})
+	}
+
+	require.Equal(t, allFields, VisibleFields(context.Background(), employee))
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+// Audit is append-only on purpose: there is no way to update or delete events.
+type Audit interface {
+	Create(ctx context.Context, event *model.AuditEvent) error
+	List(ctx context.Context, query *model.AuditEventQuery) ([]model.AuditEvent, int64, error)
+}
+
+type auditRepo struct {
+	gdb *gorm.DB
+}
+
+func NewAuditRepo(gdb *gorm.DB) Audit {
+	return &auditRepo{gdb: gdb}
+}
+
+func (r *auditRepo) Create(ctx context.Context, event *model.AuditEvent) error {
+	return conn(ctx, r.gdb).Create(event).Error
+}
+
+func (r *auditRepo) List(ctx context.Context, query *model.AuditEventQuery) ([]model.AuditEvent, int64, error) {
+	var totalCount int64
+	if err := r.filtered(ctx, query).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []model.AuditEvent
+	offset := (query.Page - 1) * query.PageSize
+	err := r.filtered(ctx, query).
+		Order("occurred_at DESC, id DESC").
+		Offset(offset).Limit(query.PageSize).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, totalCount, nil
+}
+
+func (r *auditRepo) filtered(ctx context.Context, query *model.AuditEventQuery) *gorm.DB {
+	db := conn(ctx, r.gdb).Model(&model.AuditEvent{})
+	if query.EntityType != "" {
+		db = db.Where("entity_type = ?", query.EntityType)
+	}
+	if query.EntityID != nil {
+		db = db.Where("entity_id = ?", *query.EntityID)
+	}
+	if query.ActorID != nil {
+		db = db.Where("actor_id = ?", *query.ActorID)
+	}
+	if query.From != nil {
+		db = db.Where("occurred_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("occurred_at < ?", *query.To)
+	}
+	return db
+}
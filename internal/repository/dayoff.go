@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/joremysh/fliqt/internal/model"
 )
@@ -11,11 +13,14 @@ import (
 // repositories/day_off.go
 
 type DayOff interface {
-	Create(record *model.DayOffRecord) error
-	GetByID(id uint) (*model.DayOffRecord, error)
-	Update(record *model.DayOffRecord) error
-	List(params *model.ListParams) ([]model.DayOffRecord, int64, error)
-	ExistsOverlapping(employeeID uint, startTime, endTime time.Time) (bool, error)
+	Create(ctx context.Context, record *model.DayOffRecord) error
+	GetByID(ctx context.Context, id uint) (*model.DayOffRecord, error)
+	// GetByIDForUpdate locks the record, without its employee, until the transaction of ctx ends.
+	GetByIDForUpdate(ctx context.Context, id uint) (*model.DayOffRecord, error)
+	Update(ctx context.Context, record *model.DayOffRecord) error
+	List(ctx context.Context, query *model.DayOffQuery) ([]model.DayOffRecord, int64, error)
+	// ListOverlapping returns the records of the employee that still hold time between startTime and endTime.
+	ListOverlapping(ctx context.Context, employeeID uint, startTime, endTime time.Time) ([]model.DayOffRecord, error)
 }
 
 type dayOffRepo struct {
@@ -28,83 +33,92 @@ func NewDayOffRepo(gdb *gorm.DB) DayOff {
 	}
 }
 
-func (r *dayOffRepo) Create(record *model.DayOffRecord) error {
-	return r.gdb.Create(record).Error
+func (r *dayOffRepo) Create(ctx context.Context, record *model.DayOffRecord) error {
+	return conn(ctx, r.gdb).Create(record).Error
 }
 
-func (r *dayOffRepo) GetByID(id uint) (*model.DayOffRecord, error) {
+func (r *dayOffRepo) GetByID(ctx context.Context, id uint) (*model.DayOffRecord, error) {
 	var record model.DayOffRecord
-	err := r.gdb.Preload("Employee").First(&record, id).Error
+	err := conn(ctx, r.gdb).Preload("Employee").First(&record, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &record, nil
 }
 
-func (r *dayOffRepo) Update(record *model.DayOffRecord) error {
-	return r.gdb.Save(record).Error
+func (r *dayOffRepo) GetByIDForUpdate(ctx context.Context, id uint) (*model.DayOffRecord, error) {
+	var record model.DayOffRecord
+	err := conn(ctx, r.gdb).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&record, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
 }
 
-func (r *dayOffRepo) List(params *model.ListParams) ([]model.DayOffRecord, int64, error) {
-	var records []model.DayOffRecord
-	var totalCount int64
-	query := r.gdb.Model(&model.DayOffRecord{})
-	countQuery := r.gdb.Model(&model.DayOffRecord{})
-
-	var listFilterColumnNames = map[string]string{"DayOffType": "day_off_type"}
-	// Apply filters
-	for _, field := range listFilterColumnNames {
-		if s, ok := params.Filters[field]; ok {
-			condition := listFilterColumnNames[field] + " like ?"
-			query = query.Where(condition, s)
-			countQuery = countQuery.Where(condition, s)
-		}
-	}
+func (r *dayOffRepo) Update(ctx context.Context, record *model.DayOffRecord) error {
+	return conn(ctx, r.gdb).Save(record).Error
+}
 
-	if err := countQuery.Count(&totalCount).Error; err != nil {
+func (r *dayOffRepo) List(ctx context.Context, query *model.DayOffQuery) ([]model.DayOffRecord, int64, error) {
+	var totalCount int64
+	if err := r.filtered(ctx, query).Count(&totalCount).Error; err != nil {
 		return nil, 0, err
 	}
 
-	sortColumnMap := map[string]string{"startTime": "start_time"}
-	if params.SortBy != "" {
-		if order, ok := sortColumnMap[params.SortBy]; ok {
-			if params.SortOrder == "desc" {
-				order += " DESC"
-			}
-			query = query.Order(order)
+	order := "start_time DESC"
+	sortColumnMap := map[string]string{"startTime": "start_time", "dayOffType": "day_off_type"}
+	if column, ok := sortColumnMap[query.SortBy]; ok {
+		order = column
+		if query.SortOrder == "desc" {
+			order += " DESC"
 		}
-	} else {
-		// Default sorting by start time descending
-		query = query.Order("start_time DESC")
 	}
 
-	offset := (params.Page - 1) * params.PageSize
-	query = query.Offset(offset).Limit(params.PageSize)
-
-	if err := query.Preload("Employee").Find(&records).Error; err != nil {
+	var records []model.DayOffRecord
+	offset := (query.Page - 1) * query.PageSize
+	err := r.filtered(ctx, query).
+		Order(order).Order("id DESC").
+		Offset(offset).Limit(query.PageSize).
+		Preload("Employee").
+		Find(&records).Error
+	if err != nil {
 		return nil, 0, err
 	}
-
 	return records, totalCount, nil
 }
 
-func (r *dayOffRepo) ExistsOverlapping(employeeID uint, startTime, endTime time.Time) (bool, error) {
-	var count int64
+func (r *dayOffRepo) filtered(ctx context.Context, query *model.DayOffQuery) *gorm.DB {
+	db := conn(ctx, r.gdb).Model(&model.DayOffRecord{}).Where("employee_id = ?", query.EmployeeID)
+	if query.From != nil {
+		db = db.Where("end_time > ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("start_time < ?", *query.To)
+	}
+	if query.StartFrom != nil {
+		db = db.Where("start_time >= ?", *query.StartFrom)
+	}
+	if query.StartTo != nil {
+		db = db.Where("start_time < ?", *query.StartTo)
+	}
+	if query.DayOffType != "" {
+		db = db.Where("day_off_type = ?", query.DayOffType)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	return db
+}
 
-	err := r.gdb.Model(&model.DayOffRecord{}).
+func (r *dayOffRepo) ListOverlapping(ctx context.Context, employeeID uint, startTime, endTime time.Time) ([]model.DayOffRecord, error) {
+	var records []model.DayOffRecord
+	err := conn(ctx, r.gdb).
 		Where("employee_id = ?", employeeID).
-		Where("deleted_at IS NULL"). // Exclude cancelled records
-		Where(
-			"(start_time BETWEEN ? AND ?) OR (end_time BETWEEN ? AND ?) OR (start_time <= ? AND end_time >= ?)",
-			startTime, endTime,
-			startTime, endTime,
-			startTime, endTime,
-		).
-		Count(&count).Error
-
+		Where("status IN ?", model.ActiveDayOffStatuses). // Exclude rejected, cancelled and withdrawn records
+		Where("start_time < ? AND end_time > ?", endTime, startTime).
+		Find(&records).Error
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-
-	return count > 0, nil
+	return records, nil
 }
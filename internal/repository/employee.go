@@ -1,27 +1,41 @@
 package repository
 
 import (
+	"context"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/joremysh/fliqt/internal/model"
 )
 
 type Employee interface {
-	Create(employee *model.Employee) error
-	GetByID(id uint) (*model.Employee, error)
-	GetByEmail(email string) (*model.Employee, error)
-	Update(employee *model.Employee) error
-	Delete(id uint) error
-	List(params *model.ListParams) ([]model.Employee, int64, error)
+	Create(ctx context.Context, employee *model.Employee) error
+	// CreateBatch inserts the employees with a single statement.
+	CreateBatch(ctx context.Context, employees []*model.Employee) error
+	GetByID(ctx context.Context, id uint) (*model.Employee, error)
+	// GetByIDForUpdate locks the employee until the transaction of ctx ends.
+	GetByIDForUpdate(ctx context.Context, id uint) (*model.Employee, error)
+	GetByEmail(ctx context.Context, email string) (*model.Employee, error)
+	Update(ctx context.Context, employee *model.Employee) error
+	List(ctx context.Context, params *model.ListParams) ([]model.Employee, int64, error)
+	// ListByManagerID returns the reports of the manager, only the active ones unless includeTerminated is set.
+	ListByManagerID(ctx context.Context, managerID uint, includeTerminated bool) ([]model.Employee, error)
+	// ListByDepartment returns the employees of the department, only the active ones unless includeTerminated is set.
+	ListByDepartment(ctx context.Context, department string, includeTerminated bool) ([]model.Employee, error)
+	// ExistingEmails returns which of the emails are already taken.
+	ExistingEmails(ctx context.Context, emails []string) ([]string, error)
+	// ExistingIDs returns which of the ids belong to an employee.
+	ExistingIDs(ctx context.Context, ids []uint) ([]uint, error)
 }
 
 type employeeRepo struct {
 	gdb *gorm.DB
 }
 
-func (r *employeeRepo) GetByEmail(email string) (*model.Employee, error) {
+func (r *employeeRepo) GetByEmail(ctx context.Context, email string) (*model.Employee, error) {
 	employee := &model.Employee{}
-	err := r.gdb.First(employee, &model.Employee{Email: email}).Error
+	err := conn(ctx, r.gdb).First(employee, &model.Employee{Email: email}).Error
 	if err != nil {
 		return nil, err
 	}
@@ -32,29 +46,42 @@ func NewEmployeeRepo(gdb *gorm.DB) Employee {
 	return &employeeRepo{gdb: gdb}
 }
 
-func (r *employeeRepo) Create(employee *model.Employee) error {
-	return r.gdb.Create(employee).Error
+func (r *employeeRepo) Create(ctx context.Context, employee *model.Employee) error {
+	return conn(ctx, r.gdb).Create(employee).Error
+}
+
+func (r *employeeRepo) CreateBatch(ctx context.Context, employees []*model.Employee) error {
+	return conn(ctx, r.gdb).Omit(clause.Associations).Create(employees).Error
 }
 
-func (r *employeeRepo) GetByID(id uint) (*model.Employee, error) {
+func (r *employeeRepo) GetByID(ctx context.Context, id uint) (*model.Employee, error) {
 	var employee model.Employee
-	err := r.gdb.First(&employee, id).Error
+	err := conn(ctx, r.gdb).First(&employee, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &employee, nil
 }
 
-func (r *employeeRepo) Update(employee *model.Employee) error {
-	return r.gdb.Save(employee).Error
+func (r *employeeRepo) GetByIDForUpdate(ctx context.Context, id uint) (*model.Employee, error) {
+	var employee model.Employee
+	err := conn(ctx, r.gdb).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&employee, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &employee, nil
 }
 
-func (r *employeeRepo) Delete(id uint) error {
-	return r.gdb.Delete(&model.Employee{}, id).Error
+func (r *employeeRepo) Update(ctx context.Context, employee *model.Employee) error {
+	return conn(ctx, r.gdb).Save(employee).Error
 }
 
-func (r *employeeRepo) List(params *model.ListParams) ([]model.Employee, int64, error) {
-	query := r.gdb
+func (r *employeeRepo) List(ctx context.Context, params *model.ListParams) ([]model.Employee, int64, error) {
+	query := conn(ctx, r.gdb)
+	if !params.IncludeTerminated {
+		// a new session lets the list and count queries below branch off safely
+		query = query.Where("employment_status = ?", model.EmploymentStatusActive).Session(&gorm.Session{})
+	}
 	countQuery := query
 
 	var listFilterColumnNames = []string{"name", "email", "department"}
@@ -90,3 +117,47 @@ func (r *employeeRepo) List(params *model.ListParams) ([]model.Employee, int64,
 	}
 	return employees, totalCount, nil
 }
+
+func (r *employeeRepo) ListByManagerID(ctx context.Context, managerID uint, includeTerminated bool) ([]model.Employee, error) {
+	var employees []model.Employee
+	query := activeUnless(conn(ctx, r.gdb), includeTerminated).Where("manager_id = ?", managerID)
+	if err := query.Order("name").Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+func (r *employeeRepo) ListByDepartment(ctx context.Context, department string, includeTerminated bool) ([]model.Employee, error) {
+	var employees []model.Employee
+	query := activeUnless(conn(ctx, r.gdb), includeTerminated).Where("department = ?", department)
+	if err := query.Order("name").Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+// activeUnless restricts the query to active employees unless includeTerminated is set.
+func activeUnless(query *gorm.DB, includeTerminated bool) *gorm.DB {
+	if includeTerminated {
+		return query
+	}
+	return query.Where("employment_status = ?", model.EmploymentStatusActive)
+}
+
+func (r *employeeRepo) ExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	var existing []string
+	if len(emails) == 0 {
+		return existing, nil
+	}
+	err := conn(ctx, r.gdb).Model(&model.Employee{}).Where("email IN ?", emails).Pluck("email", &existing).Error
+	return existing, err
+}
+
+func (r *employeeRepo) ExistingIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	var existing []uint
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	err := conn(ctx, r.gdb).Model(&model.Employee{}).Where("id IN ?", ids).Pluck("id", &existing).Error
+	return existing, err
+}
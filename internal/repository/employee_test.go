@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"log"
 	"testing"
 
@@ -46,7 +47,7 @@ func TestEmployeeRepo_Create(t *testing.T) {
 
 	repo := NewEmployeeRepo(tx)
 	employee := MockEmployee()
-	err = repo.Create(employee)
+	err = repo.Create(context.Background(), employee)
 	require.NoError(t, err)
 	require.NotNil(t, employee.ID)
 
@@ -65,11 +66,11 @@ func TestEmployeeRepo_GetByID(t *testing.T) {
 
 	repo := NewEmployeeRepo(tx)
 	employee := MockEmployee()
-	err = repo.Create(employee)
+	err = repo.Create(context.Background(), employee)
 	require.NoError(t, err)
 	require.NotNil(t, employee.ID)
 
-	check, err := repo.GetByID(employee.ID)
+	check, err := repo.GetByID(context.Background(), employee.ID)
 	require.NoError(t, err)
 	require.Equal(t, employee.ID, check.ID)
 	require.Equal(t, employee.Name, check.Name)
@@ -84,13 +85,13 @@ func TestEmployeeRepo_Update(t *testing.T) {
 
 	repo := NewEmployeeRepo(tx)
 	employee := MockEmployee()
-	err = repo.Create(employee)
+	err = repo.Create(context.Background(), employee)
 	require.NoError(t, err)
 
 	employee.Name = gofakeit.Name()
-	err = repo.Update(employee)
+	err = repo.Update(context.Background(), employee)
 	require.NoError(t, err)
-	check, err := repo.GetByID(employee.ID)
+	check, err := repo.GetByID(context.Background(), employee.ID)
 	require.NoError(t, err)
 	require.Equal(t, employee.ID, check.ID)
 	require.Equal(t, employee.Name, check.Name)
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+type EmployeeImport interface {
+	Create(ctx context.Context, employeeImport *model.EmployeeImport) error
+	GetByID(ctx context.Context, id uint) (*model.EmployeeImport, error)
+	Update(ctx context.Context, employeeImport *model.EmployeeImport) error
+}
+
+type employeeImportRepo struct {
+	gdb *gorm.DB
+}
+
+func NewEmployeeImportRepo(gdb *gorm.DB) EmployeeImport {
+	return &employeeImportRepo{gdb: gdb}
+}
+
+func (r *employeeImportRepo) Create(ctx context.Context, employeeImport *model.EmployeeImport) error {
+	return conn(ctx, r.gdb).Create(employeeImport).Error
+}
+
+func (r *employeeImportRepo) GetByID(ctx context.Context, id uint) (*model.EmployeeImport, error) {
+	var employeeImport model.EmployeeImport
+	if err := conn(ctx, r.gdb).First(&employeeImport, id).Error; err != nil {
+		return nil, err
+	}
+	return &employeeImport, nil
+}
+
+func (r *employeeImportRepo) Update(ctx context.Context, employeeImport *model.EmployeeImport) error {
+	return conn(ctx, r.gdb).Save(employeeImport).Error
+}
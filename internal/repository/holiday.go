@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+type Holiday interface {
+	Create(ctx context.Context, holiday *model.Holiday) error
+	GetByID(ctx context.Context, id uint) (*model.Holiday, error)
+	GetByDate(ctx context.Context, region string, date time.Time) (*model.Holiday, error)
+	Update(ctx context.Context, holiday *model.Holiday) error
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, query *model.HolidayQuery) ([]model.Holiday, int64, error)
+	// ListDates returns the holiday dates of a region in [from, to).
+	ListDates(ctx context.Context, region string, from, to time.Time) ([]time.Time, error)
+}
+
+type holidayRepo struct {
+	gdb *gorm.DB
+}
+
+func NewHolidayRepo(gdb *gorm.DB) Holiday {
+	return &holidayRepo{gdb: gdb}
+}
+
+func (r *holidayRepo) Create(ctx context.Context, holiday *model.Holiday) error {
+	return conn(ctx, r.gdb).Create(holiday).Error
+}
+
+func (r *holidayRepo) GetByID(ctx context.Context, id uint) (*model.Holiday, error) {
+	var holiday model.Holiday
+	if err := conn(ctx, r.gdb).First(&holiday, id).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+func (r *holidayRepo) GetByDate(ctx context.Context, region string, date time.Time) (*model.Holiday, error) {
+	var holiday model.Holiday
+	err := conn(ctx, r.gdb).
+		Where("region = ? AND date = ?", region, date.Format(time.DateOnly)).
+		First(&holiday).Error
+	if err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+func (r *holidayRepo) Update(ctx context.Context, holiday *model.Holiday) error {
+	return conn(ctx, r.gdb).Save(holiday).Error
+}
+
+func (r *holidayRepo) Delete(ctx context.Context, id uint) error {
+	return conn(ctx, r.gdb).Delete(&model.Holiday{}, id).Error
+}
+
+func (r *holidayRepo) List(ctx context.Context, query *model.HolidayQuery) ([]model.Holiday, int64, error) {
+	var totalCount int64
+	if err := r.filtered(ctx, query).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var holidays []model.Holiday
+	offset := (query.Page - 1) * query.PageSize
+	err := r.filtered(ctx, query).
+		Order("date, region").
+		Offset(offset).Limit(query.PageSize).
+		Find(&holidays).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return holidays, totalCount, nil
+}
+
+func (r *holidayRepo) filtered(ctx context.Context, query *model.HolidayQuery) *gorm.DB {
+	db := conn(ctx, r.gdb).Model(&model.Holiday{})
+	if query.Region != "" {
+		db = db.Where("region = ?", query.Region)
+	}
+	if query.From != nil {
+		db = db.Where("date >= ?", query.From.Format(time.DateOnly))
+	}
+	if query.To != nil {
+		db = db.Where("date < ?", query.To.Format(time.DateOnly))
+	}
+	return db
+}
+
+func (r *holidayRepo) ListDates(ctx context.Context, region string, from, to time.Time) ([]time.Time, error) {
+	var dates []time.Time
+	err := conn(ctx, r.gdb).Model(&model.Holiday{}).
+		Where("region = ? AND date >= ? AND date < ?", region, from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Order("date").
+		Pluck("date", &dates).Error
+	return dates, err
+}
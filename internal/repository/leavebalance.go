@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+type LeaveBalance interface {
+	CreateEntry(ctx context.Context, entry *model.LeaveLedgerEntry) error
+	// CreateAccrual books the yearly accrual of a day off type, unless the employee already has it.
+	CreateAccrual(ctx context.Context, entry *model.LeaveLedgerEntry) error
+	ListBalances(ctx context.Context, employeeID uint, year int) ([]model.LeaveBalance, error)
+	SumByDayOffRecord(ctx context.Context, dayOffRecordID uint) (float64, error)
+}
+
+type leaveBalanceRepo struct {
+	gdb *gorm.DB
+}
+
+func NewLeaveBalanceRepo(gdb *gorm.DB) LeaveBalance {
+	return &leaveBalanceRepo{gdb: gdb}
+}
+
+func (r *leaveBalanceRepo) CreateEntry(ctx context.Context, entry *model.LeaveLedgerEntry) error {
+	return conn(ctx, r.gdb).Create(entry).Error
+}
+
+func (r *leaveBalanceRepo) CreateAccrual(ctx context.Context, entry *model.LeaveLedgerEntry) error {
+	// accruals are unique per employee, year and day off type
+	return conn(ctx, r.gdb).Clauses(clause.OnConflict{DoNothing: true}).Create(entry).Error
+}
+
+func (r *leaveBalanceRepo) ListBalances(ctx context.Context, employeeID uint, year int) ([]model.LeaveBalance, error) {
+	var balances []model.LeaveBalance
+	err := conn(ctx, r.gdb).Model(&model.LeaveLedgerEntry{}).
+		Select("day_off_type, year, "+
+			"COALESCE(SUM(CASE WHEN kind = ? THEN days ELSE 0 END), 0) AS entitled, "+
+			"COALESCE(-SUM(CASE WHEN kind <> ? THEN days ELSE 0 END), 0) AS used, "+
+			"COALESCE(SUM(days), 0) AS remaining",
+			model.LeaveEntryAccrual, model.LeaveEntryAccrual).
+		Where("employee_id = ? AND year = ?", employeeID, year).
+		Group("day_off_type, year").
+		Order("day_off_type").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *leaveBalanceRepo) SumByDayOffRecord(ctx context.Context, dayOffRecordID uint) (float64, error) {
+	var sum float64
+	err := conn(ctx, r.gdb).Model(&model.LeaveLedgerEntry{}).
+		Select("COALESCE(SUM(days), 0)").
+		Where("day_off_record_id = ?", dayOffRecordID).
+		Scan(&sum).Error
+	if err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+type LeaveType interface {
+	Create(ctx context.Context, leaveType *model.LeaveType) error
+	GetByID(ctx context.Context, id uint) (*model.LeaveType, error)
+	GetByCode(ctx context.Context, code string) (*model.LeaveType, error)
+	Update(ctx context.Context, leaveType *model.LeaveType) error
+	Delete(ctx context.Context, id uint) error
+	// List returns the leave types by code, only the active ones unless inactive is set.
+	List(ctx context.Context, inactive bool) ([]model.LeaveType, error)
+	// IsUsed reports whether day off records or leave ledger entries refer to the code.
+	IsUsed(ctx context.Context, code string) (bool, error)
+}
+
+type leaveTypeRepo struct {
+	gdb *gorm.DB
+}
+
+func NewLeaveTypeRepo(gdb *gorm.DB) LeaveType {
+	return &leaveTypeRepo{gdb: gdb}
+}
+
+func (r *leaveTypeRepo) Create(ctx context.Context, leaveType *model.LeaveType) error {
+	return conn(ctx, r.gdb).Create(leaveType).Error
+}
+
+func (r *leaveTypeRepo) GetByID(ctx context.Context, id uint) (*model.LeaveType, error) {
+	var leaveType model.LeaveType
+	if err := conn(ctx, r.gdb).First(&leaveType, id).Error; err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
+func (r *leaveTypeRepo) GetByCode(ctx context.Context, code string) (*model.LeaveType, error) {
+	var leaveType model.LeaveType
+	if err := conn(ctx, r.gdb).Where("code = ?", code).First(&leaveType).Error; err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
+func (r *leaveTypeRepo) Update(ctx context.Context, leaveType *model.LeaveType) error {
+	return conn(ctx, r.gdb).Save(leaveType).Error
+}
+
+func (r *leaveTypeRepo) Delete(ctx context.Context, id uint) error {
+	return conn(ctx, r.gdb).Delete(&model.LeaveType{}, id).Error
+}
+
+func (r *leaveTypeRepo) List(ctx context.Context, inactive bool) ([]model.LeaveType, error) {
+	db := conn(ctx, r.gdb)
+	if !inactive {
+		db = db.Where("active = ?", true)
+	}
+	var leaveTypes []model.LeaveType
+	err := db.Order("code").Find(&leaveTypes).Error
+	return leaveTypes, err
+}
+
+func (r *leaveTypeRepo) IsUsed(ctx context.Context, code string) (bool, error) {
+	var records int64
+	err := conn(ctx, r.gdb).Unscoped().Model(&model.DayOffRecord{}).
+		Where("day_off_type = ?", code).Limit(1).Count(&records).Error
+	if err != nil || records > 0 {
+		return records > 0, err
+	}
+	var entries int64
+	err = conn(ctx, r.gdb).Model(&model.LeaveLedgerEntry{}).
+		Where("day_off_type = ?", code).Limit(1).Count(&entries).Error
+	return entries > 0, err
+}
@@ -1,21 +1,48 @@
 package repository
 
 import (
+	"context"
+	"embed"
+	"io/fs"
+
 	"gorm.io/gorm"
 
-	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/pkg/migrate"
 )
 
-func Migrate(gdb *gorm.DB) error {
-	err := gdb.AutoMigrate(&model.Employee{}, &model.DayOffRecord{})
+// migrationFiles holds the schema migrations. Model changes need a new pair of files here,
+// the schema is never derived from the models.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations returns the schema migrations, ordered by version.
+func Migrations() ([]migrate.Migration, error) {
+	files, err := fs.Sub(migrationFiles, "migrations")
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return migrate.Load(files)
+}
+
+// NewMigrator returns a migrator for the schema migrations.
+func NewMigrator(gdb *gorm.DB, options migrate.Options) (*migrate.Migrator, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	db, err := gdb.DB()
+	if err != nil {
+		return nil, err
 	}
+	return migrate.New(db, migrations, options), nil
+}
 
-	for _, seed := range All() {
-		if err = seed.Run(gdb); err != nil {
-			return err
-		}
+// Migrate applies every pending migration.
+func Migrate(gdb *gorm.DB) error {
+	migrator, err := NewMigrator(gdb, migrate.Options{})
+	if err != nil {
+		return err
 	}
-	return nil
+	return migrator.Up(context.Background())
 }
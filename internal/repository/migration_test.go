@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+// baselineSchema is the schema AutoMigrate created for the models of the first release.
+var baselineSchema = []string{
+	"CREATE TABLE `employees` (`id` bigint unsigned AUTO_INCREMENT,`name` varchar(50) NOT NULL,`email` varchar(100) NOT NULL," +
+		"`phone_number` varchar(20) NOT NULL,`department` varchar(50) NOT NULL,`title` varchar(50) NOT NULL,`level` varchar(50) NOT NULL," +
+		"`address` varchar(255) NOT NULL,`salary` mediumint unsigned NOT NULL,`onboard_date` datetime(3) NOT NULL," +
+		"`created_at` datetime(3) NULL,`updated_at` datetime(3) NULL,PRIMARY KEY (`id`)," +
+		"INDEX `idx_employees_name` (`name`),UNIQUE INDEX `idx_employees_email` (`email`))",
+	"CREATE TABLE `day_off_records` (`id` bigint unsigned AUTO_INCREMENT,`created_at` datetime(3) NULL,`updated_at` datetime(3) NULL," +
+		"`deleted_at` datetime(3) NULL,`employee_id` bigint unsigned,`day_off_type` varchar(50),`reason` longtext," +
+		"`start_time` datetime(3) NULL,`end_time` datetime(3) NULL,PRIMARY KEY (`id`)," +
+		"INDEX `idx_day_off_records_deleted_at` (`deleted_at`),INDEX `idx_day_off_records_start_time` (`start_time`)," +
+		"CONSTRAINT `fk_day_off_records_employee` FOREIGN KEY (`employee_id`) REFERENCES `employees`(`id`))",
+}
+
+// openDatabase creates an empty database on the test server, dropped when the test ends.
+func openDatabase(t *testing.T, name string) *gorm.DB {
+	require.NoError(t, gdb.Exec("CREATE DATABASE "+name).Error)
+	t.Cleanup(func() {
+		gdb.Exec("DROP DATABASE " + name)
+	})
+	dsn := strings.Replace(gdb.Dialector.(*mysql.Dialector).DSN, "/mysql?", "/"+name+"?", 1)
+	db, err := gorm.Open(mysql.Open(dsn))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestMigrate_AdoptsBaselineSchema(t *testing.T) {
+	db := openDatabase(t, "baseline")
+	for _, statement := range baselineSchema {
+		require.NoError(t, db.Exec(statement).Error)
+	}
+	now := time.Now()
+	require.NoError(t, db.Exec("INSERT INTO `employees` (`name`, `email`, `phone_number`, `department`, `title`, `level`, `address`, "+
+		"`salary`, `onboard_date`, `created_at`, `updated_at`) VALUES ('Ann', 'ann@example.com', '0900', 'Sales', 'Rep', 'Junior', 'Taipei', 50, ?, ?, ?)",
+		now.AddDate(-2, 0, 0), now, now).Error)
+	require.NoError(t, db.Exec("INSERT INTO `day_off_records` (`created_at`, `updated_at`, `employee_id`, `day_off_type`, `reason`, `start_time`, `end_time`) "+
+		"VALUES (?, ?, 1, 'PTO', 'vacation', ?, ?)", now, now, now.AddDate(0, 0, -7), now.AddDate(0, 0, -6)).Error)
+	// cancelled by soft deleting it, which appended the cancellation reason to the reason
+	require.NoError(t, db.Exec("INSERT INTO `day_off_records` (`created_at`, `updated_at`, `deleted_at`, `employee_id`, `day_off_type`, `reason`, `start_time`, `end_time`) "+
+		"VALUES (?, ?, ?, 1, 'PTO', 'wedding (Cancelled: postponed)', ?, ?)", now, now, now, now.AddDate(0, 0, 7), now.AddDate(0, 0, 8)).Error)
+
+	require.NoError(t, Migrate(db))
+
+	employee, err := NewEmployeeRepo(db).GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, model.EmploymentStatusActive, employee.EmploymentStatus)
+	require.Nil(t, employee.ManagerID)
+	// the day offs of the first release were final once submitted
+	record, err := NewDayOffRepo(db).GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "vacation", record.Reason)
+	require.Equal(t, model.DayOffStatusApproved, record.Status)
+	cancelled, err := NewDayOffRepo(db).GetByID(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, model.DayOffStatusCancelled, cancelled.Status)
+	require.Equal(t, "wedding", cancelled.Reason)
+	require.Equal(t, "postponed", cancelled.CancellationReason)
+	require.NotNil(t, cancelled.CancelledAt)
+}
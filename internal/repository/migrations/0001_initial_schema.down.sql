@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `audit_events`;
+DROP TABLE IF EXISTS `leave_ledger_entries`;
+DROP TABLE IF EXISTS `day_off_records`;
+DROP TABLE IF EXISTS `employees`;
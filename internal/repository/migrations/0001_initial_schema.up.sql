@@ -0,0 +1,125 @@
+-- Matches the schema previously created by GORM's AutoMigrate, so databases created that way
+-- can adopt the migrations: the tables are only created when they do not exist yet, and the
+-- columns the first releases did not have yet are added to them further down.
+CREATE TABLE IF NOT EXISTS `employees` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL,
+  `email` varchar(100) NOT NULL,
+  `phone_number` varchar(20) NOT NULL,
+  `department` varchar(50) NOT NULL,
+  `title` varchar(50) NOT NULL,
+  `level` varchar(50) NOT NULL,
+  `address` varchar(255) NOT NULL,
+  `salary` mediumint unsigned NOT NULL,
+  `onboard_date` datetime(3) NOT NULL,
+  `manager_id` bigint unsigned NULL,
+  `employment_status` varchar(20) NOT NULL DEFAULT 'active',
+  `termination_date` datetime(3) NULL,
+  `termination_reason` varchar(255),
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_employees_name` (`name`),
+  UNIQUE INDEX `idx_employees_email` (`email`),
+  INDEX `idx_employees_manager_id` (`manager_id`),
+  INDEX `idx_employees_employment_status` (`employment_status`),
+  CONSTRAINT `fk_employees_manager` FOREIGN KEY (`manager_id`) REFERENCES `employees` (`id`) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS `day_off_records` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `employee_id` bigint unsigned,
+  `day_off_type` varchar(50),
+  `reason` longtext,
+  `start_time` datetime(3) NULL,
+  `end_time` datetime(3) NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'pending',
+  `reviewer_id` bigint unsigned,
+  `reviewed_at` datetime(3) NULL,
+  `review_comment` varchar(255),
+  PRIMARY KEY (`id`),
+  INDEX `idx_day_off_records_deleted_at` (`deleted_at`),
+  INDEX `idx_day_off_records_start_time` (`start_time`),
+  INDEX `idx_day_off_records_status` (`status`),
+  CONSTRAINT `fk_day_off_records_employee` FOREIGN KEY (`employee_id`) REFERENCES `employees` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `leave_ledger_entries` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `employee_id` bigint unsigned NOT NULL,
+  `year` bigint NOT NULL,
+  `day_off_type` varchar(50) NOT NULL,
+  `kind` varchar(20) NOT NULL,
+  `days` decimal(6,2) NOT NULL,
+  `day_off_record_id` bigint unsigned,
+  `note` varchar(255),
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_leave_ledger_balance` (`employee_id`, `year`, `day_off_type`),
+  INDEX `idx_leave_ledger_entries_day_off_record_id` (`day_off_record_id`),
+  CONSTRAINT `fk_leave_ledger_entries_employee` FOREIGN KEY (`employee_id`) REFERENCES `employees` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `audit_events` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `occurred_at` datetime(3) NOT NULL,
+  `actor_id` bigint unsigned,
+  `actor_role` varchar(20),
+  `entity_type` varchar(50) NOT NULL,
+  `entity_id` bigint unsigned NOT NULL,
+  `operation` varchar(20) NOT NULL,
+  `changes` json,
+  PRIMARY KEY (`id`),
+  INDEX `idx_audit_events_occurred_at` (`occurred_at`),
+  INDEX `idx_audit_events_actor_id` (`actor_id`),
+  INDEX `idx_audit_entity` (`entity_type`, `entity_id`)
+);
+
+-- Databases of the first release have employees and day off records without the management,
+-- employment and approval columns. Each group of columns is added when its first one is missing;
+-- MySQL has no ADD COLUMN IF NOT EXISTS, so the statement is prepared conditionally.
+SET @adopt = IF(
+  NOT EXISTS (SELECT 1 FROM information_schema.COLUMNS
+              WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'employees' AND COLUMN_NAME = 'manager_id'),
+  'ALTER TABLE `employees`
+     ADD COLUMN `manager_id` bigint unsigned NULL AFTER `onboard_date`,
+     ADD INDEX `idx_employees_manager_id` (`manager_id`),
+     ADD CONSTRAINT `fk_employees_manager` FOREIGN KEY (`manager_id`) REFERENCES `employees` (`id`) ON DELETE SET NULL',
+  'DO 0');
+PREPARE adopt FROM @adopt;
+EXECUTE adopt;
+DEALLOCATE PREPARE adopt;
+
+SET @adopt = IF(
+  NOT EXISTS (SELECT 1 FROM information_schema.COLUMNS
+              WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'employees' AND COLUMN_NAME = 'employment_status'),
+  'ALTER TABLE `employees`
+     ADD COLUMN `employment_status` varchar(20) NOT NULL DEFAULT ''active'' AFTER `manager_id`,
+     ADD COLUMN `termination_date` datetime(3) NULL AFTER `employment_status`,
+     ADD COLUMN `termination_reason` varchar(255) AFTER `termination_date`,
+     ADD INDEX `idx_employees_employment_status` (`employment_status`)',
+  'DO 0');
+PREPARE adopt FROM @adopt;
+EXECUTE adopt;
+DEALLOCATE PREPARE adopt;
+
+-- Day offs recorded before the approval workflow were final once submitted, so the rows there are
+-- approved; the requests made from now on start out pending.
+SET @adopt = IF(
+  NOT EXISTS (SELECT 1 FROM information_schema.COLUMNS
+              WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'day_off_records' AND COLUMN_NAME = 'status'),
+  'ALTER TABLE `day_off_records`
+     ADD COLUMN `status` varchar(20) NOT NULL DEFAULT ''approved'' AFTER `end_time`,
+     ADD COLUMN `reviewer_id` bigint unsigned AFTER `status`,
+     ADD COLUMN `reviewed_at` datetime(3) NULL AFTER `reviewer_id`,
+     ADD COLUMN `review_comment` varchar(255) AFTER `reviewed_at`,
+     ADD INDEX `idx_day_off_records_status` (`status`)',
+  'DO 0');
+PREPARE adopt FROM @adopt;
+EXECUTE adopt;
+DEALLOCATE PREPARE adopt;
+
+ALTER TABLE `day_off_records` ALTER COLUMN `status` SET DEFAULT 'pending';
@@ -0,0 +1,16 @@
+CREATE TABLE `employee_imports` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `status` varchar(20) NOT NULL,
+  `dry_run` boolean NOT NULL,
+  `total_rows` bigint NOT NULL,
+  `processed_rows` bigint NOT NULL,
+  `imported_rows` bigint NOT NULL,
+  `failed_rows` bigint NOT NULL,
+  `errors` json,
+  `error` varchar(255),
+  `created_by` bigint unsigned NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `finished_at` datetime(3) NULL,
+  PRIMARY KEY (`id`)
+);
@@ -0,0 +1,8 @@
+UPDATE `day_off_records`
+SET `reason` = CONCAT(`reason`, ' (Cancelled: ', `cancellation_reason`, ')')
+WHERE `cancellation_reason` IS NOT NULL AND `cancellation_reason` <> '';
+
+ALTER TABLE `day_off_records`
+  DROP COLUMN `cancellation_reason`,
+  DROP COLUMN `cancelled_by`,
+  DROP COLUMN `cancelled_at`;
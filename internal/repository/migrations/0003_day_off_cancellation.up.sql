@@ -0,0 +1,12 @@
+ALTER TABLE `day_off_records`
+  ADD COLUMN `cancelled_at` datetime(3) NULL,
+  ADD COLUMN `cancelled_by` bigint unsigned NULL,
+  ADD COLUMN `cancellation_reason` varchar(255);
+
+-- Cancellations used to be recorded by appending " (Cancelled: <reason>)" to the reason. The
+-- assignments run in order, so the reason is only cut after the cancellation reason was copied.
+UPDATE `day_off_records`
+SET `cancellation_reason` = LEFT(SUBSTRING_INDEX(`reason`, ' (Cancelled: ', -1), LEAST(CHAR_LENGTH(SUBSTRING_INDEX(`reason`, ' (Cancelled: ', -1)) - 1, 255)),
+    `reason` = SUBSTRING(`reason`, 1, CHAR_LENGTH(`reason`) - CHAR_LENGTH(SUBSTRING_INDEX(`reason`, ' (Cancelled: ', -1)) - 13),
+    `cancelled_at` = `updated_at`
+WHERE `status` IN ('cancelled', 'withdrawn') AND `reason` LIKE '% (Cancelled: %)';
@@ -0,0 +1,6 @@
+ALTER TABLE `leave_ledger_entries` MODIFY `days` decimal(6,2) NOT NULL;
+
+ALTER TABLE `day_off_records`
+  DROP COLUMN `duration_days`,
+  DROP COLUMN `duration_hours`,
+  DROP COLUMN `half_day`;
@@ -0,0 +1,18 @@
+ALTER TABLE `day_off_records`
+  ADD COLUMN `half_day` varchar(2),
+  ADD COLUMN `duration_hours` decimal(8,2) NOT NULL DEFAULT 0,
+  ADD COLUMN `duration_days` decimal(9,4) NOT NULL DEFAULT 0;
+
+ALTER TABLE `leave_ledger_entries` MODIFY `days` decimal(9,4) NOT NULL;
+
+-- Existing requests keep the duration they were debited with, which counted calendar days,
+-- so that cancelling them gives back what was taken. Their hours assume eight hour days.
+UPDATE `day_off_records` r
+JOIN (
+  SELECT `day_off_record_id`, -SUM(`days`) AS `days`
+  FROM `leave_ledger_entries`
+  WHERE `kind` = 'debit' AND `day_off_record_id` IS NOT NULL
+  GROUP BY `day_off_record_id`
+) d ON d.`day_off_record_id` = r.`id`
+SET r.`duration_days` = d.`days`,
+    r.`duration_hours` = d.`days` * 8;
@@ -0,0 +1,3 @@
+ALTER TABLE `employees` DROP COLUMN `region`;
+
+DROP TABLE `holidays`;
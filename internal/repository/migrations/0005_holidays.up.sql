@@ -0,0 +1,12 @@
+CREATE TABLE `holidays` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `region` varchar(10) NOT NULL,
+  `date` date NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_holidays_region_date` (`region`, `date`)
+);
+
+ALTER TABLE `employees` ADD COLUMN `region` varchar(10) NOT NULL DEFAULT '' AFTER `onboard_date`;
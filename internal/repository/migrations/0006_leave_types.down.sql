@@ -0,0 +1,3 @@
+ALTER TABLE `day_off_records` DROP COLUMN `attachment_url`;
+
+DROP TABLE `leave_types`;
@@ -0,0 +1,29 @@
+CREATE TABLE `leave_types` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `code` varchar(50) NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `paid` tinyint(1) NOT NULL,
+  `requires_attachment` tinyint(1) NOT NULL,
+  `requires_approval` tinyint(1) NOT NULL,
+  `max_consecutive_days` bigint NOT NULL DEFAULT 0,
+  `min_notice_days` bigint NOT NULL DEFAULT 0,
+  `min_service_months` bigint NOT NULL DEFAULT 0,
+  `entitlement` varchar(20) NOT NULL,
+  `annual_days` decimal(6,2) NOT NULL DEFAULT 0,
+  `active` tinyint(1) NOT NULL DEFAULT 1,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_leave_types_code` (`code`)
+);
+
+-- The types that were built in before, with the same entitlements.
+INSERT INTO `leave_types`
+  (`code`, `name`, `paid`, `requires_attachment`, `requires_approval`, `entitlement`, `annual_days`, `active`, `created_at`, `updated_at`)
+VALUES
+  ('PTO', 'Paid time off', 1, 0, 1, 'seniority', 0, 1, NOW(3), NOW(3)),
+  ('sick leave', 'Sick leave', 1, 0, 1, 'flat', 30, 1, NOW(3), NOW(3)),
+  ('parental leave', 'Parental leave', 1, 0, 1, 'flat', 30, 1, NOW(3), NOW(3)),
+  ('bereavement', 'Bereavement leave', 1, 0, 1, 'flat', 8, 1, NOW(3), NOW(3));
+
+ALTER TABLE `day_off_records` ADD COLUMN `attachment_url` varchar(2048) NOT NULL DEFAULT '' AFTER `reason`;
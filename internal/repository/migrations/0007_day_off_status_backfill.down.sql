@@ -0,0 +1,2 @@
+-- The backfilled statuses are kept, they describe the records correctly either way.
+SELECT 1;
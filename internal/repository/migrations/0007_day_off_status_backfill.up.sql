@@ -0,0 +1,14 @@
+-- Day offs used to be cancelled by soft deleting them and appending " (Cancelled: <reason>)" to the
+-- reason. Only those rows are soft deleted, as requests are closed by their status since; they become
+-- cancelled requests that are listed again. The assignments run in order, so the reason is only cut
+-- after the cancellation reason was copied.
+UPDATE `day_off_records`
+SET `cancellation_reason` = LEFT(SUBSTRING_INDEX(`reason`, ' (Cancelled: ', -1), LEAST(CHAR_LENGTH(SUBSTRING_INDEX(`reason`, ' (Cancelled: ', -1)) - 1, 255)),
+    `reason` = SUBSTRING(`reason`, 1, CHAR_LENGTH(`reason`) - CHAR_LENGTH(SUBSTRING_INDEX(`reason`, ' (Cancelled: ', -1)) - 13)
+WHERE `deleted_at` IS NOT NULL AND `reason` LIKE '% (Cancelled: %)';
+
+UPDATE `day_off_records`
+SET `status` = 'cancelled',
+    `cancelled_at` = `deleted_at`,
+    `deleted_at` = NULL
+WHERE `deleted_at` IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE `leave_ledger_entries`
+  DROP INDEX `idx_leave_ledger_accrual`,
+  DROP COLUMN `accrual_day_off_type`;
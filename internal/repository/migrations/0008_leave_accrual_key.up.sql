@@ -0,0 +1,16 @@
+-- Concurrent balance lookups could book the yearly accrual of a type twice. The extra ones are
+-- dropped before every accrual is made unique; debits and credits share the table, so the key is a
+-- generated column that is only set for accruals.
+DELETE e FROM `leave_ledger_entries` e
+JOIN `leave_ledger_entries` first
+  ON first.`employee_id` = e.`employee_id`
+ AND first.`year` = e.`year`
+ AND first.`day_off_type` = e.`day_off_type`
+ AND first.`kind` = e.`kind`
+ AND first.`id` < e.`id`
+WHERE e.`kind` = 'accrual';
+
+ALTER TABLE `leave_ledger_entries`
+  ADD COLUMN `accrual_day_off_type` varchar(50)
+    GENERATED ALWAYS AS (CASE WHEN `kind` = 'accrual' THEN `day_off_type` END) VIRTUAL,
+  ADD UNIQUE INDEX `idx_leave_ledger_accrual` (`employee_id`, `year`, `accrual_day_off_type`);
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Transactor runs work in a database transaction. Repositories called with the context the work
+// is given take part in the transaction, whatever connection they were built with.
+type Transactor interface {
+	// Transaction commits when fn returns nil and rolls back otherwise. Nested calls run in
+	// a savepoint of the enclosing transaction.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type transactor struct {
+	gdb *gorm.DB
+}
+
+func NewTransactor(gdb *gorm.DB) Transactor {
+	return &transactor{gdb: gdb}
+}
+
+func (t *transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, t.gdb).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction of the context, or gdb outside of transactions.
+func conn(ctx context.Context, gdb *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return gdb.WithContext(ctx)
+}
@@ -0,0 +1,32 @@
+// Package routes resolves the OpenAPI operation served by a gin route.
+package routes
+
+import (
+	"regexp"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+var pathParameter = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Operations maps "METHOD /gin/:route" to the operation id declared in the spec.
+type Operations map[string]string
+
+func NewOperations(swagger *openapi3.T) Operations {
+	operations := make(Operations)
+	for path, item := range swagger.Paths.Map() {
+		route := pathParameter.ReplaceAllString(path, ":$1")
+		for method, operation := range item.Operations() {
+			operations[method+" "+route] = operation.OperationID
+		}
+	}
+	return operations
+}
+
+// Lookup returns the operation id of the route matched for c. Routing happens before the
+// middlewares run, so it can be called before c.Next.
+func (o Operations) Lookup(c *gin.Context) (string, bool) {
+	id, ok := o[c.Request.Method+" "+c.FullPath()]
+	return id, ok
+}
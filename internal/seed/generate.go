@@ -0,0 +1,484 @@
+// Package seed generates and loads fixture data for demos and load tests. The same profile,
+// size, RNG seed and reference date always produce the same dataset.
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+
+	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/service"
+	"github.com/joremysh/fliqt/internal/worktime"
+)
+
+// Options configures Generate. Employees overrides the size of the profile; Now is the
+// reference date the history is generated around and defaults to the current time. Day offs
+// fall on the working hours of Schedule, the standard schedule by default, and are of those
+// LeaveTypes the generator knows reasons for: PTO, sick leave, bereavement and parental leave.
+type Options struct {
+	Profile    Profile
+	Employees  int
+	Seed       uint64
+	Now        time.Time
+	Schedule   worktime.Schedule
+	LeaveTypes []model.LeaveType
+}
+
+// Dataset is a generated set of employees and day offs. Relations are kept as indexes
+// until Load knows the database IDs.
+type Dataset struct {
+	employees  []employee
+	dayOffs    []dayOff
+	leaveTypes []model.LeaveType
+}
+
+type employee struct {
+	model *model.Employee
+	// manager is the index of the direct manager, -1 for the head of the company.
+	manager int
+	// depth is the distance from the head of the company; managers are loaded before their reports.
+	depth int
+}
+
+type dayOff struct {
+	record   *model.DayOffRecord
+	employee int
+	reviewer int
+}
+
+func (d *Dataset) Employees() int {
+	return len(d.employees)
+}
+
+func (d *Dataset) DayOffs() int {
+	return len(d.dayOffs)
+}
+
+// MinEmployees is the smallest dataset with the head of the company and one director per department.
+var MinEmployees = 1 + len(departments)
+
+type department struct {
+	name   api.EmployeeDepartment
+	role   string
+	weight int
+}
+
+// departments covers every api.EmployeeDepartment with its share of the headcount.
+var departments = []department{
+	{name: api.EmployeeDepartmentEngineering, role: "Software Engineer", weight: 40},
+	{name: api.EmployeeDepartmentSales, role: "Account Executive", weight: 25},
+	{name: api.EmployeeDepartmentGeneralAffairs, role: "Administrative Specialist", weight: 15},
+	{name: api.EmployeeDepartmentDesign, role: "Product Designer", weight: 10},
+	{name: api.EmployeeDepartmentFinancial, role: "Financial Analyst", weight: 10},
+}
+
+const (
+	levelJunior    = "Junior"
+	levelMid       = "Mid"
+	levelSenior    = "Senior"
+	levelStaff     = "Staff"
+	levelPrincipal = "Principal"
+	levelManager   = "Manager"
+	levelDirector  = "Director"
+)
+
+// individualLevels are the levels of individual contributors, weighted by how common they are.
+var individualLevels = []weighted[string]{
+	{value: levelJunior, weight: 20},
+	{value: levelMid, weight: 35},
+	{value: levelSenior, weight: 30},
+	{value: levelStaff, weight: 10},
+	{value: levelPrincipal, weight: 5},
+}
+
+// salaryRanges are monthly salaries in NTD thousands, the unit of Employee.Salary is NTD per month.
+var salaryRanges = map[string][2]int{
+	levelJunior:    {40, 60},
+	levelMid:       {55, 90},
+	levelSenior:    {80, 130},
+	levelStaff:     {110, 160},
+	levelPrincipal: {140, 200},
+	levelManager:   {100, 170},
+	levelDirector:  {150, 250},
+}
+
+// reportsPerManager is the team size above which a department gets managers between
+// its director and the individual contributors.
+const reportsPerManager = 8
+
+type leaveKind struct {
+	dayOffType string
+	weight     int
+	minDays    int
+	maxDays    int
+	reasons    []string
+}
+
+var leaveKinds = []leaveKind{
+	{dayOffType: "PTO", weight: 70, minDays: 1, maxDays: 5, reasons: []string{"Vacation", "Family trip", "Personal errands", "Moving house", "Long weekend"}},
+	{dayOffType: "sick leave", weight: 24, minDays: 1, maxDays: 2, reasons: []string{"Flu", "Doctor's appointment", "Migraine", "Dental surgery"}},
+	{dayOffType: "bereavement", weight: 3, minDays: 1, maxDays: 3, reasons: []string{"Funeral of a relative"}},
+	{dayOffType: "parental leave", weight: 3, minDays: 5, maxDays: 5, reasons: []string{"Parental leave"}},
+}
+
+type weighted[T any] struct {
+	value  T
+	weight int
+}
+
+type generator struct {
+	options    Options
+	rand       *rand.Rand
+	faker      *gofakeit.Faker
+	dataset    *Dataset
+	leaveKinds []weighted[leaveKind]
+}
+
+// Generate builds a dataset. It does not touch the database.
+func Generate(options Options) (*Dataset, error) {
+	if options.Employees == 0 {
+		options.Employees = options.Profile.Employees
+	}
+	if options.Employees < MinEmployees {
+		return nil, fmt.Errorf("seed: at least %d employees are needed to staff every department", MinEmployees)
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	options.Now = options.Now.UTC()
+	if options.Schedule.Location == nil {
+		options.Schedule = worktime.Standard()
+	}
+
+	g := &generator{
+		options:    options,
+		rand:       rand.New(rand.NewPCG(options.Seed, options.Seed)),
+		faker:      gofakeit.New(options.Seed),
+		dataset:    &Dataset{leaveTypes: options.LeaveTypes},
+		leaveKinds: weightedLeaveKinds(options.LeaveTypes),
+	}
+	g.employees()
+	for i := range g.dataset.employees {
+		g.dayOffs(i)
+	}
+	// IDs follow the order of submission, as they would have
+	slices.SortStableFunc(g.dataset.dayOffs, func(a, b dayOff) int {
+		return a.record.CreatedAt.Compare(b.record.CreatedAt)
+	})
+	return g.dataset, nil
+}
+
+// employees builds the organisation: the head of the company, one director per department,
+// managers for larger departments and individual contributors reporting to them.
+func (g *generator) employees() {
+	head := g.addEmployee(api.EmployeeDepartmentGeneralAffairs, "Chief Executive Officer", levelDirector, -1, 0, 12, 15)
+
+	headcounts := headcounts(g.options.Employees - 1)
+	for i, dept := range departments {
+		director := g.addEmployee(dept.name, "Director of "+string(dept.name), levelDirector, head, 1, 3, 12)
+
+		staff := headcounts[i] - 1
+		managers := staff / (reportsPerManager + 1)
+		var leads []int
+		for range managers {
+			leads = append(leads, g.addEmployee(dept.name, string(dept.name)+" Manager", levelManager, director, 2, 1, 8))
+		}
+		for j := range staff - managers {
+			manager, depth := director, 2
+			if len(leads) > 0 {
+				manager, depth = leads[j%len(leads)], 3
+			}
+			level := pick(g.rand, individualLevels)
+			g.addEmployee(dept.name, title(level, dept.role), level, manager, depth, 0, 6)
+		}
+	}
+}
+
+// headcounts splits n employees across the departments by weight, at least one each.
+func headcounts(n int) []int {
+	var total int
+	for _, dept := range departments {
+		total += dept.weight
+	}
+	counts := make([]int, len(departments))
+	assigned := 0
+	for i, dept := range departments {
+		counts[i] = max(1, n*dept.weight/total)
+		assigned += counts[i]
+	}
+	// rounding is settled by the largest department
+	counts[0] += n - assigned
+	return counts
+}
+
+func title(level, role string) string {
+	switch level {
+	case levelMid:
+		return role
+	default:
+		return level + " " + role
+	}
+}
+
+// addEmployee appends an employee who joined between minYears and maxYears ago.
+func (g *generator) addEmployee(dept api.EmployeeDepartment, jobTitle, level string, manager, depth int, minYears, maxYears float64) int {
+	index := len(g.dataset.employees)
+	first, last := g.faker.FirstName(), g.faker.LastName()
+	tenure := minYears + g.rand.Float64()*(maxYears-minYears)
+	// nobody joins on the reference date itself, so everyone has some history
+	onboard := g.options.Now.Add(-time.Duration(tenure*365*24)*time.Hour - 24*time.Hour)
+	salary := salaryRanges[level]
+	if manager == -1 {
+		salary = [2]int{300, 300}
+	}
+
+	e := &model.Employee{
+		Name:             first + " " + last,
+		Email:            fmt.Sprintf("%s.%s.%d@example.com", emailPart(first), emailPart(last), index+1),
+		PhoneNumber:      g.faker.Phone(),
+		Department:       string(dept),
+		Title:            jobTitle,
+		Level:            level,
+		Address:          g.faker.Address().Address,
+		Salary:           (salary[0] + g.rand.IntN(salary[1]-salary[0]+1)) * 1000,
+		OnboardDate:      time.Date(onboard.Year(), onboard.Month(), onboard.Day(), 0, 0, 0, 0, time.UTC),
+		EmploymentStatus: model.EmploymentStatusActive,
+	}
+	g.dataset.employees = append(g.dataset.employees, employee{model: e, manager: manager, depth: depth})
+	return index
+}
+
+func emailPart(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// dayOffs generates the day off history of an employee, from the start of the profile's
+// history or their onboarding up to a quarter after the reference date. Requests never
+// overlap and never exceed the entitlement of their year.
+func (g *generator) dayOffs(index int) {
+	e := g.dataset.employees[index]
+	// the head of the company has nobody to review their requests
+	if e.manager == -1 || len(g.leaveKinds) == 0 {
+		return
+	}
+
+	from := g.options.Now.Add(-g.options.Profile.History)
+	if e.model.OnboardDate.After(from) {
+		from = e.model.OnboardDate
+	}
+	to := g.options.Now.AddDate(0, 3, 0)
+	years := to.Sub(from).Hours() / 24 / 365
+	requests := int(math.Round(g.options.Profile.RequestsPerYear * years * (0.5 + g.rand.Float64())))
+
+	used := make(map[string]float64)
+	var taken [][2]time.Time
+	for range requests {
+		kind := pick(g.rand, g.leaveKinds)
+		// a few attempts to find a free slot within the budget, then the request is dropped
+		for range 5 {
+			start, end, half := g.slot(kind, from, to)
+			key := fmt.Sprintf("%d/%s", start.Year(), kind.dayOffType)
+			_, days := g.options.Schedule.Leave(start, end, half)
+			overdrawn := isPaid(g.options.LeaveTypes, kind.dayOffType) &&
+				used[key]+days > service.AnnualEntitlements(g.options.LeaveTypes, e.model, start.Year())[kind.dayOffType]
+			if overdrawn || overlaps(taken, start, end) {
+				continue
+			}
+			used[key] += days
+			taken = append(taken, [2]time.Time{start, end})
+			g.addDayOff(index, kind, start, end, half)
+			break
+		}
+	}
+}
+
+// weightedLeaveKinds returns the leave kinds that are among the active leave types.
+func weightedLeaveKinds(leaveTypes []model.LeaveType) []weighted[leaveKind] {
+	var kinds []weighted[leaveKind]
+	for _, kind := range leaveKinds {
+		if slices.ContainsFunc(leaveTypes, func(leaveType model.LeaveType) bool {
+			return leaveType.Code == kind.dayOffType && leaveType.Active
+		}) {
+			kinds = append(kinds, weighted[leaveKind]{value: kind, weight: kind.weight})
+		}
+	}
+	return kinds
+}
+
+// isPaid reports whether the leave type of the code is counted against a balance.
+func isPaid(leaveTypes []model.LeaveType, code string) bool {
+	return slices.ContainsFunc(leaveTypes, func(leaveType model.LeaveType) bool {
+		return leaveType.Code == code && leaveType.Paid
+	})
+}
+
+// slot picks the working hours of one to a few consecutive working days, or half a day.
+func (g *generator) slot(kind leaveKind, from, to time.Time) (time.Time, time.Time, worktime.HalfDay) {
+	var day time.Time
+	profile := g.options.Profile
+	if len(profile.PeakMonths) > 0 && g.rand.Float64() < profile.PeakShare {
+		day = g.peakDay(from, to)
+	}
+	if day.IsZero() {
+		day = from.Add(time.Duration(g.rand.Int64N(int64(to.Sub(from)))))
+	}
+	schedule := g.options.Schedule
+	day = day.In(schedule.Location)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, schedule.Location)
+	for !schedule.IsWorkingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+
+	if kind.dayOffType == "PTO" && g.rand.IntN(10) == 0 {
+		half := worktime.AM
+		if g.rand.IntN(2) == 0 {
+			half = worktime.PM
+		}
+		start, end := schedule.HalfDay(day, half)
+		return start, end, half
+	}
+	days := kind.minDays + g.rand.IntN(kind.maxDays-kind.minDays+1)
+	// stop before the next day off, usually the weekend
+	last := day
+	for range days - 1 {
+		if !schedule.IsWorkingDay(last.AddDate(0, 0, 1)) {
+			break
+		}
+		last = last.AddDate(0, 0, 1)
+	}
+	return day.Add(schedule.DayStart), last.Add(schedule.DayEnd), ""
+}
+
+// peakDay picks a day in one of the peak months within the range, or returns zero if there is none.
+func (g *generator) peakDay(from, to time.Time) time.Time {
+	var candidates []time.Time
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); month.Before(to); month = month.AddDate(0, 1, 0) {
+		for _, peak := range g.options.Profile.PeakMonths {
+			if month.Month() == peak {
+				candidates = append(candidates, month)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return time.Time{}
+	}
+	month := candidates[g.rand.IntN(len(candidates))]
+	day := month.AddDate(0, 0, g.rand.IntN(month.AddDate(0, 1, -1).Day()))
+	if day.Before(from) || !day.Before(to) {
+		return time.Time{}
+	}
+	return day
+}
+
+func overlaps(taken [][2]time.Time, start, end time.Time) bool {
+	for _, t := range taken {
+		if start.Before(t[1]) && t[0].Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// addDayOff records a request submitted a few days to a month ahead, with a status that
+// fits its dates: past requests were mostly approved, upcoming ones are often still pending.
+func (g *generator) addDayOff(index int, kind leaveKind, start, end time.Time, half worktime.HalfDay) {
+	manager := g.dataset.employees[index].manager
+	submitted := start.Add(-time.Duration(3+g.rand.IntN(28)) * 24 * time.Hour).Add(-time.Duration(g.rand.IntN(8)) * time.Hour)
+	record := &model.DayOffRecord{
+		DayOffType: kind.dayOffType,
+		Reason:     kind.reasons[g.rand.IntN(len(kind.reasons))],
+		StartTime:  start,
+		EndTime:    end,
+		HalfDay:    string(half),
+		Status:     model.DayOffStatusPending,
+	}
+	record.DurationHours, record.DurationDays = g.options.Schedule.Leave(start, end, half)
+	record.CreatedAt, record.UpdatedAt = submitted, submitted
+
+	var status string
+	if start.After(g.options.Now) {
+		status = pick(g.rand, []weighted[string]{
+			{value: model.DayOffStatusPending, weight: 50},
+			{value: model.DayOffStatusApproved, weight: 40},
+			{value: model.DayOffStatusWithdrawn, weight: 10},
+		})
+	} else {
+		status = pick(g.rand, []weighted[string]{
+			{value: model.DayOffStatusApproved, weight: 85},
+			{value: model.DayOffStatusRejected, weight: 5},
+			{value: model.DayOffStatusWithdrawn, weight: 5},
+			{value: model.DayOffStatusCancelled, weight: 5},
+		})
+	}
+	// requests still pending in the past would never have been left open
+	if submitted.After(g.options.Now) {
+		status = model.DayOffStatusPending
+		record.CreatedAt, record.UpdatedAt = g.options.Now, g.options.Now
+	}
+
+	reviewer := -1
+	reviewed := record.CreatedAt.Add(time.Duration(1+g.rand.IntN(48)) * time.Hour)
+	switch status {
+	case model.DayOffStatusApproved, model.DayOffStatusCancelled:
+		reviewer = manager
+		record.ReviewedAt = &reviewed
+		record.ReviewComment = "Enjoy"
+	case model.DayOffStatusRejected:
+		reviewer = manager
+		record.ReviewedAt = &reviewed
+		record.ReviewComment = "Too many people are out that week"
+	}
+	if record.ReviewedAt != nil && record.ReviewedAt.After(g.options.Now) {
+		// the review would lie in the future, leave the request open instead
+		status, reviewer, record.ReviewedAt, record.ReviewComment = model.DayOffStatusPending, -1, nil, ""
+	}
+	record.Status = status
+	if record.ReviewedAt != nil {
+		record.UpdatedAt = *record.ReviewedAt
+	}
+	if status == model.DayOffStatusCancelled || status == model.DayOffStatusWithdrawn {
+		// the employee called it off before the leave started
+		until := start
+		if g.options.Now.Before(until) {
+			until = g.options.Now
+		}
+		cancelled := record.UpdatedAt
+		if span := until.Sub(cancelled); span > 0 {
+			cancelled = cancelled.Add(time.Duration(g.rand.Int64N(int64(span))))
+		}
+		record.CancelledAt, record.UpdatedAt = &cancelled, cancelled
+		record.CancellationReason = "No longer needed"
+		if status == model.DayOffStatusCancelled {
+			record.CancellationReason = "Plans changed"
+		}
+	}
+	g.dataset.dayOffs = append(g.dataset.dayOffs, dayOff{record: record, employee: index, reviewer: reviewer})
+}
+
+func pick[T any](r *rand.Rand, values []weighted[T]) T {
+	var total int
+	for _, v := range values {
+		total += v.weight
+	}
+	roll := r.IntN(total)
+	for _, v := range values {
+		if roll < v.weight {
+			return v.value
+		}
+		roll -= v.weight
+	}
+	return values[len(values)-1].value
+}
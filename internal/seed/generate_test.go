@@ -0,0 +1,147 @@
+package seed
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/api"
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/service"
+)
+
+var referenceDate = time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+
+// leaveTypes are the leave types a freshly migrated database offers.
+var leaveTypes = []model.LeaveType{
+	{Code: "PTO", Paid: true, Entitlement: model.LeaveEntitlementSeniority, Active: true},
+	{Code: "sick leave", Paid: true, Entitlement: model.LeaveEntitlementFlat, AnnualDays: 30, Active: true},
+	{Code: "parental leave", Paid: true, Entitlement: model.LeaveEntitlementFlat, AnnualDays: 30, Active: true},
+	{Code: "bereavement", Paid: true, Entitlement: model.LeaveEntitlementFlat, AnnualDays: 8, Active: true},
+}
+
+func generate(t *testing.T, profile string, employees int, seed uint64) *Dataset {
+	p, ok := LookupProfile(profile)
+	require.True(t, ok)
+	dataset, err := Generate(Options{Profile: p, Employees: employees, Seed: seed, Now: referenceDate, LeaveTypes: leaveTypes})
+	require.NoError(t, err)
+	return dataset
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	a := generate(t, "small-team", 0, 42)
+	b := generate(t, "small-team", 0, 42)
+	c := generate(t, "small-team", 0, 43)
+
+	require.Equal(t, a, b)
+	require.NotEqual(t, a.employees[0].model.Name, c.employees[0].model.Name)
+}
+
+func TestGenerate_Organisation(t *testing.T) {
+	dataset := generate(t, "small-team", 200, 1)
+	require.Equal(t, 200, dataset.Employees())
+
+	departments := make(map[string]int)
+	emails := make(map[string]bool)
+	for i, e := range dataset.employees {
+		departments[e.model.Department]++
+		require.NotEmpty(t, e.model.Title)
+		require.NotEmpty(t, e.model.Level)
+		require.False(t, emails[e.model.Email], "duplicate email %s", e.model.Email)
+		emails[e.model.Email] = true
+		require.True(t, e.model.OnboardDate.Before(referenceDate))
+
+		if i == 0 {
+			require.Equal(t, -1, e.manager)
+			continue
+		}
+		// managers come first and sit one level up
+		require.Less(t, e.manager, i)
+		require.Equal(t, dataset.employees[e.manager].depth+1, e.depth)
+	}
+	for _, dept := range []api.EmployeeDepartment{
+		api.EmployeeDepartmentDesign, api.EmployeeDepartmentEngineering, api.EmployeeDepartmentFinancial,
+		api.EmployeeDepartmentGeneralAffairs, api.EmployeeDepartmentSales,
+	} {
+		require.Positive(t, departments[string(dept)], dept)
+	}
+}
+
+func TestGenerate_DayOffs(t *testing.T) {
+	dataset := generate(t, "leave-season", 0, 7)
+	require.NotZero(t, dataset.DayOffs())
+
+	used := make(map[string]float64)
+	byEmployee := make(map[int][]*model.DayOffRecord)
+	var peak int
+	for _, d := range dataset.dayOffs {
+		record := d.record
+		e := dataset.employees[d.employee]
+		require.True(t, record.StartTime.Before(record.EndTime))
+		require.False(t, record.StartTime.Before(e.model.OnboardDate))
+
+		for _, other := range byEmployee[d.employee] {
+			require.False(t, record.StartTime.Before(other.EndTime) && other.StartTime.Before(record.EndTime), "overlapping day offs")
+		}
+		byEmployee[d.employee] = append(byEmployee[d.employee], record)
+
+		key := fmt.Sprintf("%d/%d/%s", d.employee, record.StartTime.Year(), record.DayOffType)
+		require.Positive(t, record.DurationDays)
+		used[key] += record.DurationDays
+		require.LessOrEqual(t, used[key], service.AnnualEntitlements(leaveTypes, e.model, record.StartTime.Year())[record.DayOffType])
+
+		switch record.Status {
+		case model.DayOffStatusApproved, model.DayOffStatusRejected, model.DayOffStatusCancelled:
+			require.Equal(t, e.manager, d.reviewer)
+			require.NotNil(t, record.ReviewedAt)
+			require.False(t, record.ReviewedAt.After(referenceDate))
+		default:
+			require.Equal(t, -1, d.reviewer)
+		}
+		if record.Status == model.DayOffStatusCancelled || record.Status == model.DayOffStatusWithdrawn {
+			require.NotNil(t, record.CancelledAt)
+			require.NotEmpty(t, record.CancellationReason)
+			require.False(t, record.CancelledAt.After(referenceDate))
+			require.False(t, record.CancelledAt.After(record.StartTime))
+		} else {
+			require.Nil(t, record.CancelledAt)
+		}
+		if record.StartTime.After(referenceDate) {
+			require.NotContains(t, []string{model.DayOffStatusRejected, model.DayOffStatusCancelled}, record.Status)
+		}
+		if month := record.StartTime.Month(); month == time.July || month == time.August || month == time.December {
+			peak++
+		}
+	}
+	// three of twelve months get well over half of the requests
+	require.Greater(t, float64(peak)/float64(dataset.DayOffs()), 0.5)
+}
+
+func TestGenerate_TooSmall(t *testing.T) {
+	profile, _ := LookupProfile("small-team")
+	_, err := Generate(Options{Profile: profile, Employees: MinEmployees - 1})
+	require.Error(t, err)
+}
+
+func TestLedger(t *testing.T) {
+	dataset := generate(t, "small-team", 0, 3)
+	entries := ledger(dataset)
+
+	// every request is debited, and given back unless it holds its slot
+	net := make(map[*uint]float64)
+	for _, entry := range entries {
+		if entry.Kind != model.LeaveEntryAccrual {
+			net[entry.DayOffRecordID] += entry.Days
+		}
+	}
+	for _, d := range dataset.dayOffs {
+		held := d.record.Status == model.DayOffStatusPending || d.record.Status == model.DayOffStatusApproved
+		if held {
+			require.Negative(t, net[&d.record.ID])
+		} else {
+			require.Zero(t, net[&d.record.ID])
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/service"
+)
+
+// ErrNotEmpty is returned by Load when the database already has employees; seeding is meant
+// for fresh demo and load test databases only.
+var ErrNotEmpty = errors.New("seed: the database already has employees")
+
+const batchSize = 500
+
+// Load inserts the dataset in a single transaction, together with the leave ledger the
+// services would have booked: yearly accruals, a debit per request and a credit for every
+// request that did not go through.
+func Load(ctx context.Context, gdb *gorm.DB, dataset *Dataset) error {
+	return gdb.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.Employee{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrNotEmpty
+		}
+
+		if err := loadEmployees(tx, dataset); err != nil {
+			return err
+		}
+		records := make([]*model.DayOffRecord, len(dataset.dayOffs))
+		for i, d := range dataset.dayOffs {
+			d.record.EmployeeID = dataset.employees[d.employee].model.ID
+			if d.record.CancelledAt != nil {
+				d.record.CancelledBy = &d.record.EmployeeID
+			}
+			if d.reviewer >= 0 {
+				d.record.ReviewerID = &dataset.employees[d.reviewer].model.ID
+			}
+			records[i] = d.record
+		}
+		if len(records) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(records, batchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		entries := ledger(dataset)
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).CreateInBatches(entries, batchSize).Error
+	})
+}
+
+// loadEmployees inserts the employees level by level, so that every manager has an ID
+// before their reports are inserted.
+func loadEmployees(tx *gorm.DB, dataset *Dataset) error {
+	for depth := 0; ; depth++ {
+		var level []*model.Employee
+		for _, e := range dataset.employees {
+			if e.depth != depth {
+				continue
+			}
+			if e.manager >= 0 {
+				e.model.ManagerID = &dataset.employees[e.manager].model.ID
+			}
+			level = append(level, e.model)
+		}
+		if len(level) == 0 {
+			return nil
+		}
+		if err := tx.Omit(clause.Associations).CreateInBatches(level, batchSize).Error; err != nil {
+			return fmt.Errorf("employees at depth %d: %w", depth, err)
+		}
+	}
+}
+
+// ledger books the entitlements of every year with requests, and the movements of every request of paid leave.
+func ledger(dataset *Dataset) []*model.LeaveLedgerEntry {
+	var entries []*model.LeaveLedgerEntry
+	accrued := make(map[string]bool)
+	for _, d := range dataset.dayOffs {
+		e := dataset.employees[d.employee].model
+		year := d.record.StartTime.Year()
+		if key := fmt.Sprintf("%d/%d", d.employee, year); !accrued[key] {
+			accrued[key] = true
+			entitlements := service.AnnualEntitlements(dataset.leaveTypes, e, year)
+			for _, leaveType := range dataset.leaveTypes {
+				if !leaveType.Active || !leaveType.Paid {
+					continue
+				}
+				entries = append(entries, &model.LeaveLedgerEntry{
+					EmployeeID: e.ID,
+					Year:       year,
+					DayOffType: leaveType.Code,
+					Kind:       model.LeaveEntryAccrual,
+					Days:       entitlements[leaveType.Code],
+					Note:       fmt.Sprintf("%d entitlement", year),
+					CreatedAt:  d.record.CreatedAt,
+				})
+			}
+		}
+
+		if !isPaid(dataset.leaveTypes, d.record.DayOffType) {
+			continue
+		}
+		days := d.record.DurationDays
+		entries = append(entries, &model.LeaveLedgerEntry{
+			EmployeeID:     e.ID,
+			Year:           year,
+			DayOffType:     d.record.DayOffType,
+			Kind:           model.LeaveEntryDebit,
+			Days:           -days,
+			DayOffRecordID: &d.record.ID,
+			CreatedAt:      d.record.CreatedAt,
+		})
+		switch d.record.Status {
+		case model.DayOffStatusRejected, model.DayOffStatusWithdrawn, model.DayOffStatusCancelled:
+			entries = append(entries, &model.LeaveLedgerEntry{
+				EmployeeID:     e.ID,
+				Year:           year,
+				DayOffType:     d.record.DayOffType,
+				Kind:           model.LeaveEntryCredit,
+				Days:           days,
+				DayOffRecordID: &d.record.ID,
+				Note:           "day off " + d.record.Status,
+				CreatedAt:      closedAt(d.record),
+			})
+		}
+	}
+	return entries
+}
+
+// closedAt is when a request that did not go through was closed.
+func closedAt(record *model.DayOffRecord) time.Time {
+	if record.ReviewedAt != nil && record.Status == model.DayOffStatusRejected {
+		return *record.ReviewedAt
+	}
+	if record.CancelledAt != nil {
+		return *record.CancelledAt
+	}
+	return record.UpdatedAt
+}
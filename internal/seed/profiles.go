@@ -0,0 +1,62 @@
+package seed
+
+import (
+	"slices"
+	"time"
+)
+
+// Profile describes the shape of a generated dataset.
+type Profile struct {
+	Name        string
+	Description string
+	Employees   int
+	// History is how far back day offs go; requests are also made for the next quarter.
+	History time.Duration
+	// RequestsPerYear is the average number of day off requests per employee and year.
+	RequestsPerYear float64
+	// PeakMonths, when set, receive PeakShare of the requests.
+	PeakMonths []time.Month
+	PeakShare  float64
+}
+
+var profiles = map[string]Profile{
+	"small-team": {
+		Name:            "small-team",
+		Description:     "a dozen people with two years of ordinary leave",
+		Employees:       12,
+		History:         2 * 365 * 24 * time.Hour,
+		RequestsPerYear: 4,
+	},
+	"company": {
+		Name:            "company",
+		Description:     "10k employees with a year of ordinary leave, for load tests",
+		Employees:       10000,
+		History:         365 * 24 * time.Hour,
+		RequestsPerYear: 4,
+	},
+	"leave-season": {
+		Name:            "leave-season",
+		Description:     "300 employees taking most of their frequent leave in summer and around new year",
+		Employees:       300,
+		History:         365 * 24 * time.Hour,
+		RequestsPerYear: 8,
+		PeakMonths:      []time.Month{time.July, time.August, time.December},
+		PeakShare:       0.7,
+	},
+}
+
+// LookupProfile returns the profile with the given name.
+func LookupProfile(name string) (Profile, bool) {
+	profile, ok := profiles[name]
+	return profile, ok
+}
+
+// ProfileNames lists the profiles in alphabetical order.
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/joremysh/fliqt/internal/auth"
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+)
+
+type AuditService interface {
+	// Record stores a mutation of an entity. before is nil for creations, after is nil for deletions.
+	Record(ctx context.Context, entityType string, entityID uint, operation string, before, after any) error
+	ListEvents(ctx context.Context, query *model.AuditEventQuery) (*PaginatedResult[model.AuditEvent], error)
+}
+
+// FieldChange is the value of a field before and after a mutation.
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// ignoredAuditFields are bookkeeping columns and preloaded associations, which are not part of the diff.
+var ignoredAuditFields = map[string]bool{
+	"CreatedAt": true,
+	"UpdatedAt": true,
+	"Employee":  true,
+	"Manager":   true,
+}
+
+type auditService struct {
+	repo repository.Audit
+}
+
+func NewAuditService(repo repository.Audit) AuditService {
+	return &auditService{repo: repo}
+}
+
+func (s *auditService) Record(ctx context.Context, entityType string, entityID uint, operation string, before, after any) error {
+	ctx, span := tracer.Start(ctx, "AuditService.Record")
+	defer span.End()
+
+	changes, err := diffFields(before, after)
+	if err != nil {
+		return err
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	event := &model.AuditEvent{
+		OccurredAt: time.Now(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  operation,
+		Changes:    string(changesJSON),
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		event.ActorID = &principal.EmployeeID
+		event.ActorRole = principal.Role
+	}
+	return s.repo.Create(ctx, event)
+}
+
+func (s *auditService) ListEvents(ctx context.Context, query *model.AuditEventQuery) (*PaginatedResult[model.AuditEvent], error) {
+	ctx, span := tracer.Start(ctx, "AuditService.ListEvents")
+	defer span.End()
+
+	events, total, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &PaginatedResult[model.AuditEvent]{
+		Data:       events,
+		TotalCount: total,
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+	}, nil
+}
+
+// diffFields compares the exported fields of two snapshots of the same entity and returns
+// the ones that differ. Either side may be nil.
+func diffFields(before, after any) (map[string]FieldChange, error) {
+	beforeFields, err := toFieldMap(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFieldMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]FieldChange)
+	for name, value := range afterFields {
+		if old, ok := beforeFields[name]; !ok || !reflect.DeepEqual(old, value) {
+			changes[name] = FieldChange{Before: beforeFields[name], After: value}
+		}
+	}
+	for name, old := range beforeFields {
+		if _, ok := afterFields[name]; !ok {
+			changes[name] = FieldChange{Before: old}
+		}
+	}
+	return changes, nil
+}
+
+func toFieldMap(entity any) (map[string]any, error) {
+	fields := make(map[string]any)
+	if value := reflect.ValueOf(entity); entity == nil || (value.Kind() == reflect.Pointer && value.IsNil()) {
+		return fields, nil
+	}
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for name := range ignoredAuditFields {
+		delete(fields, name)
+	}
+	return fields, nil
+}
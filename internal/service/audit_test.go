@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/internal/auth"
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/pkg/cache"
+)
+
+func TestDiffFields(t *testing.T) {
+	before := &model.Employee{ID: 1, Name: "Ann", Salary: 100}
+	after := &model.Employee{ID: 1, Name: "Ann", Salary: 120}
+
+	changes, err := diffFields(before, after)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, float64(100), changes["Salary"].Before)
+	require.Equal(t, float64(120), changes["Salary"].After)
+
+	changes, err = diffFields(nil, after)
+	require.NoError(t, err)
+	require.Equal(t, "Ann", changes["Name"].After)
+	require.Nil(t, changes["Name"].Before)
+	require.NotContains(t, changes, "CreatedAt")
+}
+
+func TestAuditService_Record(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	repo = repository.NewEmployeeRepo(tx)
+	auditSvc := NewAuditService(repository.NewAuditRepo(tx))
+	svc := NewEmployeeService(repo, repository.NewTransactor(tx), cache.NewMemory(100, time.Minute), auditSvc, newDayOffService(tx))
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{EmployeeID: 42, Role: auth.RoleHRAdmin})
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-1, 0, 0)
+	created, err := svc.CreateEmployee(ctx, employee)
+	require.NoError(t, err)
+	_, err = svc.TerminateEmployee(ctx, created.ID, time.Now(), "resigned")
+	require.NoError(t, err)
+
+	actorID := uint(42)
+	result, err := auditSvc.ListEvents(ctx, &model.AuditEventQuery{
+		Page:       1,
+		PageSize:   10,
+		EntityType: model.AuditEntityEmployee,
+		EntityID:   &created.ID,
+		ActorID:    &actorID,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, result.TotalCount)
+	require.Equal(t, model.AuditOperationTerminate, result.Data[0].Operation)
+	require.Equal(t, model.AuditOperationCreate, result.Data[1].Operation)
+	require.Equal(t, auth.RoleHRAdmin, result.Data[0].ActorRole)
+}
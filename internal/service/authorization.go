@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"github.com/joremysh/fliqt/internal/auth"
+	"github.com/joremysh/fliqt/internal/model"
+)
+
+var ErrForbidden = newForbiddenError("forbidden", "not allowed to access this employee's records")
+
+// Ownership rules are enforced here rather than in the transport, because only the
+// services know who owns a record. Callers without a principal are internal and trusted.
+
+// authorizeSelf allows the employee themselves and HR admins.
+func authorizeSelf(ctx context.Context, employeeID uint) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsHRAdmin() || principal.EmployeeID == employeeID {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// authorizeSelfOrManager additionally allows the employee's direct manager.
+func authorizeSelfOrManager(ctx context.Context, employee *model.Employee) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsHRAdmin() || principal.EmployeeID == employee.ID {
+		return nil
+	}
+	if principal.Role == auth.RoleManager && employee.ManagerID != nil && *employee.ManagerID == principal.EmployeeID {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// authorizeReviewer allows HR admins and the direct manager of the employee to review requests.
+func authorizeReviewer(ctx context.Context, employee *model.Employee) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsHRAdmin() {
+		return nil
+	}
+	if employee.ManagerID != nil && *employee.ManagerID == principal.EmployeeID {
+		return nil
+	}
+	return ErrForbidden
+}
@@ -9,57 +9,160 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/joremysh/fliqt/internal/auth"
 	"github.com/joremysh/fliqt/internal/model"
 	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/internal/worktime"
 )
 
 type DayOffService interface {
 	SubmitDayOff(ctx context.Context, record *model.DayOffRecord) (*model.DayOffRecord, error)
-	ListDayOffs(ctx context.Context, employeeID uint, params *model.ListParams) (*PaginatedResult[model.DayOffRecord], error)
-	CancelDayOff(ctx context.Context, id uint, cancellationReason string) error
+	ListDayOffs(ctx context.Context, query *model.DayOffQuery) (*PaginatedResult[model.DayOffRecord], error)
+	GetDayOff(ctx context.Context, id uint) (*model.DayOffRecord, error)
+	CancelDayOff(ctx context.Context, id uint, cancellationReason string) (*model.DayOffRecord, error)
+	ApproveDayOff(ctx context.Context, id uint, reviewerID uint, comment string) (*model.DayOffRecord, error)
+	RejectDayOff(ctx context.Context, id uint, reviewerID uint, comment string) (*model.DayOffRecord, error)
+	// CloseAfterTermination withdraws the pending and cancels the approved requests of a terminated employee
+	// that end after the termination date, giving their leave back.
+	CloseAfterTermination(ctx context.Context, employee *model.Employee) error
 }
 
 type dayOffService struct {
-	repo         repository.DayOff
-	employeeRepo repository.Employee
+	repo                repository.DayOff
+	transactor          repository.Transactor
+	employeeRepo        repository.Employee
+	holidayRepo         repository.Holiday
+	leaveTypeRepo       repository.LeaveType
+	leaveBalanceService LeaveBalanceService
+	auditService        AuditService
+	schedule            worktime.Schedule
 }
 
-func NewDayOffService(repo repository.DayOff, employeeRepo repository.Employee) DayOffService {
+// NewDayOffService builds the day off service, which measures requests in the working time of schedule
+// without the holidays of the employee's region.
+func NewDayOffService(repo repository.DayOff, transactor repository.Transactor, employeeRepo repository.Employee, holidayRepo repository.Holiday, leaveTypeRepo repository.LeaveType, leaveBalanceService LeaveBalanceService, auditService AuditService, schedule worktime.Schedule) DayOffService {
 	return &dayOffService{
-		repo:         repo,
-		employeeRepo: employeeRepo,
+		repo:                repo,
+		transactor:          transactor,
+		employeeRepo:        employeeRepo,
+		holidayRepo:         holidayRepo,
+		leaveTypeRepo:       leaveTypeRepo,
+		leaveBalanceService: leaveBalanceService,
+		auditService:        auditService,
+		schedule:            schedule,
 	}
 }
 
 func (s *dayOffService) SubmitDayOff(ctx context.Context, record *model.DayOffRecord) (*model.DayOffRecord, error) {
-	_, err := s.employeeRepo.GetByID(record.EmployeeID)
+	ctx, span := tracer.Start(ctx, "DayOffService.SubmitDayOff")
+	defer span.End()
+
+	if err := authorizeSelf(ctx, record.EmployeeID); err != nil {
+		return nil, err
+	}
+
+	// the checks and the writes run in one transaction holding the employee's lock, so that
+	// concurrent requests of an employee cannot both pass the overlap and balance checks
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		return s.submit(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, record.ID)
+}
+
+// submit checks and stores the request, debiting the employee's balance.
+func (s *dayOffService) submit(ctx context.Context, record *model.DayOffRecord) error {
+	employee, err := s.employeeRepo.GetByIDForUpdate(ctx, record.EmployeeID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("employee not found by id: %d", record.EmployeeID)
+			return fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, record.EmployeeID)
 		}
-		return nil, err
+		return err
 	}
 
-	if err := s.validateDayOff(record); err != nil {
-		return nil, err
+	leaveType, err := s.validateDayOff(ctx, employee, record)
+	if err != nil {
+		return err
+	}
+	schedule, err := s.employeeSchedule(ctx, employee, record)
+	if err != nil {
+		return err
+	}
+	if err = measure(schedule, record); err != nil {
+		return err
+	}
+	// balances are kept per year and a request is booked to the year it starts in
+	nextYear := time.Date(record.StartTime.Year()+1, time.January, 1, 0, 0, 0, 0, record.StartTime.Location())
+	if schedule.WorkingTime(nextYear, record.EndTime) > 0 {
+		return ErrCrossYearDayOff
+	}
+	if leaveType.MaxConsecutiveDays > 0 && record.DurationDays > float64(leaveType.MaxConsecutiveDays) {
+		return fmt.Errorf("%w: at most %d days of %s at once", ErrTooManyConsecutiveDays, leaveType.MaxConsecutiveDays, leaveType.Code)
+	}
+	if employee.TerminationDate != nil && record.EndTime.After(*employee.TerminationDate) {
+		return ErrEmployeeTerminated
 	}
 
-	exists, err := s.repo.ExistsOverlapping(record.EmployeeID, record.StartTime, record.EndTime)
+	// requests only collide when they share working time, e.g. not when they only share a holiday
+	overlapping, err := s.repo.ListOverlapping(ctx, record.EmployeeID, record.StartTime, record.EndTime)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if exists {
-		return nil, ErrOverlappingDayOff
+	for _, other := range overlapping {
+		if schedule.WorkingTime(latest(record.StartTime, other.StartTime), earliest(record.EndTime, other.EndTime)) > 0 {
+			return ErrOverlappingDayOff
+		}
 	}
 
-	if err = s.repo.Create(record); err != nil {
-		return nil, err
+	// unpaid leave is not counted against a balance
+	if leaveType.Paid {
+		if err = s.leaveBalanceService.EnsureSufficient(ctx, employee, record); err != nil {
+			return err
+		}
+	}
+
+	record.Status = model.DayOffStatusPending
+	if !leaveType.RequiresApproval {
+		now := time.Now()
+		record.Status = model.DayOffStatusApproved
+		record.ReviewedAt = &now
+	}
+	if err = s.repo.Create(ctx, record); err != nil {
+		return err
+	}
+	// the balance is reserved on submission and given back if the request does not go through
+	if leaveType.Paid {
+		if err = s.leaveBalanceService.Debit(ctx, employee, record); err != nil {
+			return err
+		}
 	}
-	return s.repo.GetByID(record.ID)
+	return s.auditService.Record(ctx, model.AuditEntityDayOff, record.ID, model.AuditOperationCreate, nil, record)
 }
 
-func (s *dayOffService) ListDayOffs(ctx context.Context, employeeID uint, params *model.ListParams) (*PaginatedResult[model.DayOffRecord], error) {
-	records, total, err := s.repo.List(params)
+func (s *dayOffService) ListDayOffs(ctx context.Context, query *model.DayOffQuery) (*PaginatedResult[model.DayOffRecord], error) {
+	ctx, span := tracer.Start(ctx, "DayOffService.ListDayOffs")
+	defer span.End()
+
+	if query.From != nil && query.To != nil && !query.To.After(*query.From) {
+		return nil, ErrInvalidQueryRange
+	}
+	if query.StartFrom != nil && query.StartTo != nil && !query.StartTo.After(*query.StartFrom) {
+		return nil, ErrInvalidStartTimeRange
+	}
+	employee, err := s.employeeRepo.GetByID(ctx, query.EmployeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, query.EmployeeID)
+		}
+		return nil, err
+	}
+	if err = authorizeSelfOrManager(ctx, employee); err != nil {
+		return nil, err
+	}
+
+	records, total, err := s.repo.List(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -67,58 +170,300 @@ func (s *dayOffService) ListDayOffs(ctx context.Context, employeeID uint, params
 	return &PaginatedResult[model.DayOffRecord]{
 		Data:       records,
 		TotalCount: total,
-		Page:       params.Page,
-		PageSize:   params.PageSize,
+		Page:       query.Page,
+		PageSize:   query.PageSize,
 	}, nil
 }
 
-func (s *dayOffService) CancelDayOff(ctx context.Context, id uint, cancellationReason string) error {
-	record, err := s.repo.GetByID(id)
+func (s *dayOffService) GetDayOff(ctx context.Context, id uint) (*model.DayOffRecord, error) {
+	ctx, span := tracer.Start(ctx, "DayOffService.GetDayOff")
+	defer span.End()
+
+	record, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w by id: %d", ErrDayOffNotFound, id)
+		}
+		return nil, err
+	}
+	if err = authorizeSelfOrManager(ctx, &record.Employee); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *dayOffService) CancelDayOff(ctx context.Context, id uint, cancellationReason string) (*model.DayOffRecord, error) {
+	ctx, span := tracer.Start(ctx, "DayOffService.CancelDayOff")
+	defer span.End()
+
+	record, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrDayOffNotFound
+			return nil, ErrDayOffNotFound
 		}
+		return nil, err
+	}
+	if err = authorizeSelf(ctx, record.EmployeeID); err != nil {
+		return nil, err
+	}
+	if err = s.cancel(ctx, record.ID, cancellationReason); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, record.ID)
+}
+
+func (s *dayOffService) CloseAfterTermination(ctx context.Context, employee *model.Employee) error {
+	ctx, span := tracer.Start(ctx, "DayOffService.CloseAfterTermination")
+	defer span.End()
+
+	if employee.TerminationDate == nil {
+		return nil
+	}
+	// the same requests are refused on submission once the employee is terminated
+	records, err := s.repo.ListOverlapping(ctx, employee.ID, *employee.TerminationDate, maxTime)
+	if err != nil {
 		return err
 	}
+	for _, record := range records {
+		if err = s.cancel(ctx, record.ID, "employment terminated"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	record.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
-	record.Reason = fmt.Sprintf("%s (Cancelled: %s)", record.Reason, cancellationReason)
+// maxTime is later than any day off.
+var maxTime = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
 
-	return s.repo.Update(record)
+// cancel closes a request that still holds time and gives its leave back. The request is locked while
+// its status is checked, so that concurrent cancellations and reviews cannot both close and refund it.
+func (s *dayOffService) cancel(ctx context.Context, id uint, cancellationReason string) error {
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		record, err := s.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *record
+		// A pending request is withdrawn by its owner, an approved one has to be cancelled.
+		switch record.Status {
+		case model.DayOffStatusPending:
+			record.Status = model.DayOffStatusWithdrawn
+		case model.DayOffStatusApproved:
+			record.Status = model.DayOffStatusCancelled
+		default:
+			return ErrDayOffNotCancellable
+		}
+		now := time.Now()
+		record.CancelledAt = &now
+		record.CancellationReason = cancellationReason
+		if principal, ok := auth.PrincipalFromContext(ctx); ok {
+			record.CancelledBy = &principal.EmployeeID
+		}
+
+		if err = s.repo.Update(ctx, record); err != nil {
+			return err
+		}
+		if err = s.auditService.Record(ctx, model.AuditEntityDayOff, record.ID, model.AuditOperationCancel, &before, record); err != nil {
+			return err
+		}
+		return s.leaveBalanceService.Credit(ctx, record, "day off "+record.Status)
+	})
+}
+
+func (s *dayOffService) ApproveDayOff(ctx context.Context, id uint, reviewerID uint, comment string) (*model.DayOffRecord, error) {
+	ctx, span := tracer.Start(ctx, "DayOffService.ApproveDayOff")
+	defer span.End()
+
+	return s.review(ctx, id, reviewerID, model.DayOffStatusApproved, comment)
+}
+
+func (s *dayOffService) RejectDayOff(ctx context.Context, id uint, reviewerID uint, comment string) (*model.DayOffRecord, error) {
+	ctx, span := tracer.Start(ctx, "DayOffService.RejectDayOff")
+	defer span.End()
+
+	if strings.TrimSpace(comment) == "" {
+		return nil, ErrReviewCommentRequired
+	}
+	return s.review(ctx, id, reviewerID, model.DayOffStatusRejected, comment)
+}
+
+// review moves a pending record to the given final status on behalf of the reviewer.
+func (s *dayOffService) review(ctx context.Context, id uint, reviewerID uint, status string, comment string) (*model.DayOffRecord, error) {
+	record, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDayOffNotFound
+		}
+		return nil, err
+	}
+	if record.Status != model.DayOffStatusPending {
+		return nil, ErrDayOffNotPending
+	}
+	if record.EmployeeID == reviewerID {
+		return nil, ErrSelfReview
+	}
+	if err = authorizeReviewer(ctx, &record.Employee); err != nil {
+		return nil, err
+	}
+
+	if _, err = s.employeeRepo.GetByID(ctx, reviewerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w by id: %d", ErrReviewerNotFound, reviewerID)
+		}
+		return nil, err
+	}
+
+	operation := model.AuditOperationApprove
+	if status == model.DayOffStatusRejected {
+		operation = model.AuditOperationReject
+	}
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		// checked again under the lock, a concurrent review or cancellation may have closed the request
+		record, err := s.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if record.Status != model.DayOffStatusPending {
+			return ErrDayOffNotPending
+		}
+		before := *record
+		now := time.Now()
+		record.Status = status
+		record.ReviewerID = &reviewerID
+		record.ReviewedAt = &now
+		record.ReviewComment = comment
+		if err = s.repo.Update(ctx, record); err != nil {
+			return err
+		}
+		if err = s.auditService.Record(ctx, model.AuditEntityDayOff, record.ID, operation, &before, record); err != nil {
+			return err
+		}
+		if status == model.DayOffStatusRejected {
+			return s.leaveBalanceService.Credit(ctx, record, "day off rejected")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
 }
 
 // Custom errors
 var (
-	ErrEmployeeNotFound     = errors.New("employee not found")
-	ErrOverlappingDayOff    = errors.New("overlapping day off exists")
-	ErrInvalidDayOffType    = errors.New("invalid day off type")
-	ErrInvalidDateRange     = errors.New("end date must be after start date")
-	ErrPastDateNotAllowed   = errors.New("cannot submit day off for past dates")
-	ErrReasonRequired       = errors.New("reason is required")
-	ErrDayOffNotFound       = errors.New("day off record not found")
-	ErrCantCancelPastDayOff = errors.New("cannot cancel past day off")
+	ErrEmployeeNotFound         = newNotFoundError("employee_not_found", "employee not found")
+	ErrOverlappingDayOff        = newConflictError("overlapping_day_off", "startTime", "overlapping day off exists")
+	ErrInsufficientLeaveBalance = newValidationError("insufficient_leave_balance", "dayOffType", "insufficient leave balance")
+	ErrInvalidDayOffType        = newValidationError("invalid_day_off_type", "dayOffType", "invalid day off type")
+	ErrInvalidDateRange         = newValidationError("invalid_date_range", "endTime", "end date must be after start date")
+	ErrInvalidQueryRange        = newValidationError("invalid_date_range", "to", "to must not be before from")
+	ErrInvalidStartTimeRange    = newValidationError("invalid_date_range", "startTimeTo", "startTimeTo must not be before startTimeFrom")
+	ErrReasonRequired           = newValidationError("reason_required", "reason", "reason is required")
+	ErrDayOffNotFound           = newNotFoundError("day_off_not_found", "day off record not found")
+	ErrDayOffNotCancellable     = newConflictError("day_off_closed", "", "day off record is already closed")
+	ErrDayOffNotPending         = newConflictError("day_off_not_pending", "", "day off record is not pending approval")
+	ErrSelfReview               = newForbiddenError("self_review", "cannot review own day off request")
+	ErrReviewCommentRequired    = newValidationError("review_comment_required", "comment", "comment is required when rejecting a day off")
+	ErrReviewerNotFound         = newValidationError("reviewer_not_found", "reviewerID", "reviewer not found")
+	ErrEmployeeTerminated       = newConflictError("employee_terminated", "endTime", "day off ends after the employee's termination date")
+	ErrInvalidHalfDay           = newValidationError("invalid_half_day", "halfDay", "half day must be AM or PM")
+	ErrNoWorkingTime            = newValidationError("no_working_time", "endTime", "day off does not cover any working time")
+	ErrNotEligible              = newValidationError("not_eligible", "dayOffType", "the employee has not served long enough for this day off type")
+	ErrInsufficientNotice       = newValidationError("insufficient_notice", "startTime", "day off is requested too late for this day off type")
+	ErrAttachmentRequired       = newValidationError("attachment_required", "attachmentURL", "this day off type requires an attachment")
+	ErrTooManyConsecutiveDays   = newValidationError("max_consecutive_days_exceeded", "endTime", "day off is longer than this day off type allows")
+	ErrCrossYearDayOff          = newValidationError("cross_year_day_off", "endTime", "day off must end in the year it starts, request each year separately")
 )
 
-func (s *dayOffService) validateDayOff(record *model.DayOffRecord) error {
-	validTypes := map[string]bool{
-		"PTO":            true,
-		"sick leave":     true,
-		"parental leave": true,
-		"bereavement":    true,
+// validateDayOff checks the record and the rules of its leave type, except for its length, which
+// is only known once the record is measured.
+func (s *dayOffService) validateDayOff(ctx context.Context, employee *model.Employee, record *model.DayOffRecord) (*model.LeaveType, error) {
+	leaveType, err := s.leaveTypeRepo.GetByCode(ctx, record.DayOffType)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDayOffType, record.DayOffType)
 	}
-	if !validTypes[record.DayOffType] {
-		return ErrInvalidDayOffType
+	if err != nil {
+		return nil, err
+	}
+	if !leaveType.Active {
+		return nil, fmt.Errorf("%w: %s is no longer offered", ErrInvalidDayOffType, record.DayOffType)
+	}
+
+	if record.HalfDay != "" && record.HalfDay != string(worktime.AM) && record.HalfDay != string(worktime.PM) {
+		return nil, ErrInvalidHalfDay
 	}
 
-	// Validate dates
-	if record.StartTime.After(record.EndTime) {
-		return ErrInvalidDateRange
+	// Validate dates, a half day only takes the date of its start time
+	if record.HalfDay == "" && record.StartTime.After(record.EndTime) {
+		return nil, ErrInvalidDateRange
 	}
 
 	// Validate reason provided
 	if strings.TrimSpace(record.Reason) == "" {
-		return ErrReasonRequired
+		return nil, ErrReasonRequired
+	}
+
+	if leaveType.RequiresAttachment && strings.TrimSpace(record.AttachmentURL) == "" {
+		return nil, ErrAttachmentRequired
+	}
+	if completedMonths(employee.OnboardDate, record.StartTime) < leaveType.MinServiceMonths {
+		return nil, fmt.Errorf("%w: %s needs %d months of service", ErrNotEligible, leaveType.Code, leaveType.MinServiceMonths)
+	}
+	// notice is counted in days of the schedule's location, from today
+	if leaveType.MinNoticeDays > 0 {
+		now := time.Now().In(s.schedule.Location)
+		earliest := time.Date(now.Year(), now.Month(), now.Day()+leaveType.MinNoticeDays, 0, 0, 0, 0, now.Location())
+		if record.StartTime.Before(earliest) {
+			return nil, fmt.Errorf("%w: %s needs %d days of notice", ErrInsufficientNotice, leaveType.Code, leaveType.MinNoticeDays)
+		}
 	}
 
+	return leaveType, nil
+}
+
+// employeeSchedule returns the work schedule of the employee around the dates of the record, with
+// the holidays of their region.
+func (s *dayOffService) employeeSchedule(ctx context.Context, employee *model.Employee, record *model.DayOffRecord) (worktime.Schedule, error) {
+	if employee.Region == "" {
+		return s.schedule, nil
+	}
+	// the dates of the schedule's location may differ from those in UTC by a day
+	from := record.StartTime.AddDate(0, 0, -1)
+	to := record.EndTime.AddDate(0, 0, 2)
+	if record.HalfDay != "" {
+		to = record.StartTime.AddDate(0, 0, 2)
+	}
+	holidays, err := s.holidayRepo.ListDates(ctx, employee.Region, from, to)
+	if err != nil {
+		return worktime.Schedule{}, err
+	}
+	return s.schedule.WithHolidays(holidays...), nil
+}
+
+// measure sets the duration of the record in working time. Half day requests are moved to the
+// working hours of their half of the day.
+func measure(schedule worktime.Schedule, record *model.DayOffRecord) error {
+	half := worktime.HalfDay(record.HalfDay)
+	if half != "" {
+		record.StartTime, record.EndTime = schedule.HalfDay(record.StartTime, half)
+	}
+	record.DurationHours, record.DurationDays = schedule.Leave(record.StartTime, record.EndTime, half)
+	if record.DurationDays == 0 {
+		return ErrNoWorkingTime
+	}
 	return nil
 }
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
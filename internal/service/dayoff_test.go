@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/internal/worktime"
+)
+
+// nextMonday returns the start of the working day of the next Monday.
+func nextMonday() time.Time {
+	now := time.Now().UTC()
+	days := (int(time.Monday-now.Weekday())+6)%7 + 1
+	return time.Date(now.Year(), now.Month(), now.Day()+days, 9, 0, 0, 0, time.UTC)
+}
+
+// newDayOffService builds the day off service over tx, with the standard working schedule.
+func newDayOffService(tx *gorm.DB) DayOffService {
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	leaveTypeRepo := repository.NewLeaveTypeRepo(tx)
+	return NewDayOffService(repository.NewDayOffRepo(tx), repository.NewTransactor(tx), employeeRepo, repository.NewHolidayRepo(tx), leaveTypeRepo,
+		NewLeaveBalanceService(repository.NewLeaveBalanceRepo(tx), employeeRepo, leaveTypeRepo), NewAuditService(repository.NewAuditRepo(tx)), worktime.Standard())
+}
+
+func TestDayOffService_ApproveDayOff(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	svc := newDayOffService(tx)
+
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-5, 0, 0)
+	require.NoError(t, employeeRepo.Create(context.Background(), employee))
+	reviewer := repository.MockEmployee()
+	require.NoError(t, employeeRepo.Create(context.Background(), reviewer))
+
+	ctx := context.Background()
+	start := nextMonday()
+	created, err := svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "PTO",
+		Reason:     "vacation",
+		StartTime:  start,
+		EndTime:    start.Add(9 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.DayOffStatusPending, created.Status)
+	require.Equal(t, 8.0, created.DurationHours)
+	require.Equal(t, 1.0, created.DurationDays)
+
+	_, err = svc.ApproveDayOff(ctx, created.ID, employee.ID, "")
+	require.ErrorIs(t, err, ErrSelfReview)
+
+	approved, err := svc.ApproveDayOff(ctx, created.ID, reviewer.ID, "enjoy")
+	require.NoError(t, err)
+	require.Equal(t, model.DayOffStatusApproved, approved.Status)
+	require.Equal(t, reviewer.ID, *approved.ReviewerID)
+
+	_, err = svc.RejectDayOff(ctx, created.ID, reviewer.ID, "too late")
+	require.ErrorIs(t, err, ErrDayOffNotPending)
+
+	cancelled, err := svc.CancelDayOff(ctx, created.ID, "plans changed")
+	require.NoError(t, err)
+	require.Equal(t, model.DayOffStatusCancelled, cancelled.Status)
+	require.Equal(t, "plans changed", cancelled.CancellationReason)
+	require.Equal(t, "vacation", cancelled.Reason)
+	require.NotNil(t, cancelled.CancelledAt)
+	_, err = svc.CancelDayOff(ctx, created.ID, "plans changed again")
+	require.ErrorIs(t, err, ErrDayOffNotCancellable)
+
+	fetched, err := svc.GetDayOff(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, cancelled.CancelledAt, fetched.CancelledAt)
+	_, err = svc.GetDayOff(ctx, created.ID+1000)
+	require.ErrorIs(t, err, ErrDayOffNotFound)
+}
+
+func TestDayOffService_RejectDayOff(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	svc := newDayOffService(tx)
+
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-5, 0, 0)
+	require.NoError(t, employeeRepo.Create(context.Background(), employee))
+	reviewer := repository.MockEmployee()
+	require.NoError(t, employeeRepo.Create(context.Background(), reviewer))
+
+	ctx := context.Background()
+	start := nextMonday().AddDate(0, 0, 1)
+	record := &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "sick leave",
+		Reason:     "flu",
+		StartTime:  start,
+		EndTime:    start.Add(8 * time.Hour),
+	}
+	created, err := svc.SubmitDayOff(ctx, record)
+	require.NoError(t, err)
+
+	_, err = svc.RejectDayOff(ctx, created.ID, reviewer.ID, " ")
+	require.ErrorIs(t, err, ErrReviewCommentRequired)
+
+	rejected, err := svc.RejectDayOff(ctx, created.ID, reviewer.ID, "team offsite")
+	require.NoError(t, err)
+	require.Equal(t, model.DayOffStatusRejected, rejected.Status)
+
+	// a rejected request no longer blocks the slot
+	_, err = svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "sick leave",
+		Reason:     "flu",
+		StartTime:  start,
+		EndTime:    start.Add(8 * time.Hour),
+	})
+	require.NoError(t, err)
+}
+
+func TestDayOffService_SubmitDayOffDuration(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	svc := newDayOffService(tx)
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-5, 0, 0)
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+
+	// the half day takes the date of the start time, the times are those of the afternoon
+	monday := nextMonday()
+	created, err := svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "PTO",
+		Reason:     "dentist",
+		HalfDay:    "PM",
+		StartTime:  monday,
+		EndTime:    monday,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "PM", created.HalfDay)
+	require.Equal(t, monday.Add(4*time.Hour), created.StartTime.UTC())
+	require.Equal(t, monday.Add(9*time.Hour), created.EndTime.UTC())
+	require.Equal(t, 4.0, created.DurationHours)
+	require.Equal(t, 0.5, created.DurationDays)
+
+	// two hours on Tuesday, across the lunch break
+	tuesday := monday.AddDate(0, 0, 1)
+	created, err = svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "PTO",
+		Reason:     "errand",
+		StartTime:  tuesday.Add(2 * time.Hour),
+		EndTime:    tuesday.Add(5 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2.0, created.DurationHours)
+	require.Equal(t, 0.25, created.DurationDays)
+
+	_, err = svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "PTO",
+		Reason:     "weekend",
+		StartTime:  monday.AddDate(0, 0, -2),
+		EndTime:    monday.AddDate(0, 0, -1),
+	})
+	require.ErrorIs(t, err, ErrNoWorkingTime)
+
+	_, err = svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "PTO",
+		Reason:     "evening",
+		HalfDay:    "EV",
+		StartTime:  monday,
+		EndTime:    monday,
+	})
+	require.ErrorIs(t, err, ErrInvalidHalfDay)
+}
+
+func TestDayOffService_ListDayOffs(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	dayOffRepo := repository.NewDayOffRepo(tx)
+	svc := NewDayOffService(dayOffRepo, repository.NewTransactor(tx), employeeRepo, repository.NewHolidayRepo(tx), repository.NewLeaveTypeRepo(tx),
+		NewLeaveBalanceService(repository.NewLeaveBalanceRepo(tx), employeeRepo, repository.NewLeaveTypeRepo(tx)), NewAuditService(repository.NewAuditRepo(tx)), worktime.Standard())
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+	other := repository.MockEmployee()
+	require.NoError(t, employeeRepo.Create(ctx, other))
+
+	day := func(d int) time.Time {
+		return time.Date(2024, time.May, d, 9, 0, 0, 0, time.UTC)
+	}
+	records := []*model.DayOffRecord{
+		{EmployeeID: employee.ID, DayOffType: "PTO", Status: model.DayOffStatusApproved, StartTime: day(2), EndTime: day(6)},
+		{EmployeeID: employee.ID, DayOffType: "sick leave", Status: model.DayOffStatusApproved, StartTime: day(10), EndTime: day(10).Add(8 * time.Hour)},
+		{EmployeeID: employee.ID, DayOffType: "PTO", Status: model.DayOffStatusCancelled, StartTime: day(20), EndTime: day(21)},
+		{EmployeeID: other.ID, DayOffType: "PTO", Status: model.DayOffStatusApproved, StartTime: day(5), EndTime: day(6)},
+	}
+	for _, record := range records {
+		record.Reason = "reason"
+		require.NoError(t, dayOffRepo.Create(ctx, record))
+	}
+	at := func(d int) *time.Time {
+		date := time.Date(2024, time.May, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+
+	tests := []struct {
+		name  string
+		query model.DayOffQuery
+		want  []uint
+	}{
+		{"employee only", model.DayOffQuery{}, []uint{records[2].ID, records[1].ID, records[0].ID}},
+		{"overlapping", model.DayOffQuery{From: at(4), To: at(11)}, []uint{records[1].ID, records[0].ID}},
+		{"starting", model.DayOffQuery{StartFrom: at(4), StartTo: at(11)}, []uint{records[1].ID}},
+		{"type", model.DayOffQuery{DayOffType: "PTO"}, []uint{records[2].ID, records[0].ID}},
+		{"status", model.DayOffQuery{Status: model.DayOffStatusApproved, SortBy: "startTime", SortOrder: "asc"}, []uint{records[0].ID, records[1].ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			query.Page, query.PageSize, query.EmployeeID = 1, 10, employee.ID
+			result, err := svc.ListDayOffs(ctx, &query)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(tt.want)), result.TotalCount)
+			ids := make([]uint, len(result.Data))
+			for i, record := range result.Data {
+				ids[i] = record.ID
+			}
+			require.Equal(t, tt.want, ids)
+		})
+	}
+
+	_, err := svc.ListDayOffs(ctx, &model.DayOffQuery{Page: 1, PageSize: 10, EmployeeID: employee.ID, From: at(11), To: at(4)})
+	require.ErrorIs(t, err, ErrInvalidQueryRange)
+}
+
+// failingDebit is a leave balance service whose debits fail.
+type failingDebit struct {
+	LeaveBalanceService
+}
+
+func (failingDebit) Debit(context.Context, *model.Employee, *model.DayOffRecord) error {
+	return errors.New("ledger unavailable")
+}
+
+func TestDayOffService_SubmitDayOffRollsBack(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	dayOffRepo := repository.NewDayOffRepo(tx)
+	balanceSvc := NewLeaveBalanceService(repository.NewLeaveBalanceRepo(tx), employeeRepo, repository.NewLeaveTypeRepo(tx))
+	svc := NewDayOffService(dayOffRepo, repository.NewTransactor(tx), employeeRepo, repository.NewHolidayRepo(tx), repository.NewLeaveTypeRepo(tx),
+		failingDebit{balanceSvc}, NewAuditService(repository.NewAuditRepo(tx)), worktime.Standard())
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-5, 0, 0)
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+
+	countEvents := func() int64 {
+		var events int64
+		require.NoError(t, tx.Model(&model.AuditEvent{}).Where("entity_type = ?", model.AuditEntityDayOff).Count(&events).Error)
+		return events
+	}
+	events := countEvents()
+
+	start := nextMonday()
+	_, err := svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "PTO",
+		Reason:     "vacation",
+		StartTime:  start,
+		EndTime:    start.Add(9 * time.Hour),
+	})
+	require.EqualError(t, err, "ledger unavailable")
+
+	// neither the request nor its audit event outlive the failed debit
+	var records int64
+	require.NoError(t, tx.Unscoped().Model(&model.DayOffRecord{}).Where("employee_id = ?", employee.ID).Count(&records).Error)
+	require.Zero(t, records)
+	require.Equal(t, events, countEvents())
+}
+
+func TestDayOffService_SubmitDayOffAcrossYears(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	holidayRepo := repository.NewHolidayRepo(tx)
+	svc := NewDayOffService(repository.NewDayOffRepo(tx), repository.NewTransactor(tx), employeeRepo, holidayRepo, repository.NewLeaveTypeRepo(tx),
+		NewLeaveBalanceService(repository.NewLeaveBalanceRepo(tx), employeeRepo, repository.NewLeaveTypeRepo(tx)), NewAuditService(repository.NewAuditRepo(tx)), worktime.Standard())
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-5, 0, 0)
+	employee.Region = "TW"
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+
+	year := time.Now().Year() + 1
+	newYear := time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, holidayRepo.Create(ctx, &model.Holiday{Region: "TW", Date: newYear, Name: "New Year"}))
+	request := func(start, end time.Time) *model.DayOffRecord {
+		return &model.DayOffRecord{EmployeeID: employee.ID, DayOffType: "PTO", Reason: "holidays", StartTime: start, EndTime: end}
+	}
+	// the working days of the new year are booked to it, so they are requested separately
+	_, err := svc.SubmitDayOff(ctx, request(newYear.AddDate(0, 0, -7).Add(9*time.Hour), newYear.AddDate(0, 0, 14)))
+	require.ErrorIs(t, err, ErrCrossYearDayOff)
+
+	// a request ending on a holiday of the new year stays in its year
+	created, err := svc.SubmitDayOff(ctx, request(time.Date(year, time.December, 29, 9, 0, 0, 0, time.UTC), newYear.Add(18*time.Hour)))
+	require.NoError(t, err)
+	require.Equal(t, year, created.StartTime.Year())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,9 +17,21 @@ import (
 type EmployeeService interface {
 	CreateEmployee(ctx context.Context, employee *model.Employee) (*model.Employee, error)
 	GetEmployee(ctx context.Context, id uint) (*model.Employee, error)
-	DeleteEmployee(ctx context.Context, id uint) error
+	// TerminateEmployee ends the employment on a date up to today, and closes the day offs requested
+	// for after it.
+	TerminateEmployee(ctx context.Context, id uint, terminationDate time.Time, reason string) (*model.Employee, error)
+	// RehireEmployee starts a new employment after the termination date. The leave accrued for the
+	// current year is kept as it is.
+	RehireEmployee(ctx context.Context, id uint, onboardDate time.Time) (*model.Employee, error)
 	UpdateEmployee(ctx context.Context, employee *model.Employee) (*model.Employee, error)
 	ListEmployees(ctx context.Context, params *model.ListParams) (*PaginatedResult[model.Employee], error)
+	// ListDirectReports returns the employees reporting to the employee, only the active ones unless
+	// includeTerminated is set.
+	ListDirectReports(ctx context.Context, id uint, includeTerminated bool) ([]model.Employee, error)
+	GetManagementChain(ctx context.Context, id uint) ([]model.Employee, error)
+	// GetOrgChart returns the reporting trees of the department, of the active employees unless
+	// includeTerminated is set.
+	GetOrgChart(ctx context.Context, department string, includeTerminated bool) ([]*OrgChartNode, error)
 }
 
 type PaginatedResult[T any] struct {
@@ -28,101 +41,263 @@ type PaginatedResult[T any] struct {
 	PageSize   int
 }
 
+// OrgChartNode is an employee together with everyone reporting to them.
+type OrgChartNode struct {
+	Employee model.Employee
+	Reports  []*OrgChartNode
+}
+
+var (
+	ErrEmailAlreadyExists = newConflictError("email_already_exists", "email", "employee email already exists")
+	ErrManagerNotFound    = newValidationError("manager_not_found", "managerID", "manager not found")
+	ErrManagerCycle       = newValidationError("manager_cycle", "managerID", "manager assignment would create a reporting cycle")
+
+	ErrEmployeeAlreadyTerminated = newConflictError("employee_already_terminated", "", "employee is already terminated")
+	ErrEmployeeNotTerminated     = newConflictError("employee_not_terminated", "", "only terminated employees can be rehired")
+	ErrTerminationBeforeOnboard  = newValidationError("termination_before_onboard", "terminationDate", "termination date must not be before the onboard date")
+	ErrFutureTermination         = newValidationError("future_termination_date", "terminationDate", "termination date must not be after today")
+	ErrRehireBeforeTermination   = newValidationError("rehire_before_termination", "onboardDate", "onboard date must be after the termination date")
+)
+
+// employeeCacheOptions keeps employees for an hour. Unknown ids are remembered briefly so that
+// repeated lookups of a missing employee do not all reach the database.
+var employeeCacheOptions = cache.AsideOptions{
+	Name:        "employee",
+	TTL:         1 * time.Hour,
+	NegativeTTL: 1 * time.Minute,
+	Jitter:      0.1,
+}
+
 type employeeService struct {
-	repo        repository.Employee
-	redisClient *cache.RedisClient
+	repo          repository.Employee
+	transactor    repository.Transactor
+	cache         *cache.Aside
+	auditService  AuditService
+	dayOffService DayOffService
 }
 
-func NewEmployeeService(repo repository.Employee, redisClient *cache.RedisClient) EmployeeService {
+func NewEmployeeService(repo repository.Employee, transactor repository.Transactor, employeeCache cache.Cache, auditService AuditService, dayOffService DayOffService) EmployeeService {
 	return &employeeService{
-		repo:        repo,
-		redisClient: redisClient,
+		repo:          repo,
+		transactor:    transactor,
+		cache:         cache.NewAside(employeeCache, employeeCacheOptions),
+		auditService:  auditService,
+		dayOffService: dayOffService,
+	}
+}
+
+func employeeCacheKey(id uint) string {
+	return fmt.Sprintf("employee:%d", id)
+}
+
+// invalidate drops the cached employee after a write. The write has succeeded by then, so a failure
+// is only logged: the stale entry is served until it expires.
+func (e employeeService) invalidate(ctx context.Context, id uint) {
+	if err := e.cache.Invalidate(ctx, employeeCacheKey(id)); err != nil {
+		slog.WarnContext(ctx, "failed to invalidate the cached employee", "employee", id, "error", err)
 	}
 }
 
 func (e employeeService) CreateEmployee(ctx context.Context, employee *model.Employee) (*model.Employee, error) {
-	_, err := e.repo.GetByEmail(employee.Email)
+	ctx, span := tracer.Start(ctx, "EmployeeService.CreateEmployee")
+	defer span.End()
+
+	_, err := e.repo.GetByEmail(ctx, employee.Email)
 	if err == nil {
-		return nil, fmt.Errorf("employee email already exists: %s", employee.Email)
+		return nil, fmt.Errorf("%w: %s", ErrEmailAlreadyExists, employee.Email)
 	}
 	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
 	}
-
-	if err = e.repo.Create(employee); err != nil {
-		return nil, err
+	if employee.ManagerID != nil {
+		if _, err = e.getManager(ctx, *employee.ManagerID); err != nil {
+			return nil, err
+		}
 	}
 
-	created, err := e.repo.GetByID(employee.ID)
+	var created *model.Employee
+	err = e.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := e.repo.Create(ctx, employee); err != nil {
+			return err
+		}
+		var err error
+		if created, err = e.repo.GetByID(ctx, employee.ID); err != nil {
+			return err
+		}
+		return e.auditService.Record(ctx, model.AuditEntityEmployee, created.ID, model.AuditOperationCreate, nil, created)
+	})
 	if err != nil {
 		return nil, err
 	}
+	// the id may have been looked up, and cached as missing, before it existed
+	e.invalidate(ctx, created.ID)
 
 	return created, nil
 }
 
 func (e employeeService) GetEmployee(ctx context.Context, id uint) (*model.Employee, error) {
-	cacheKey := fmt.Sprintf("employee:%d", id)
-	var employee *model.Employee
-	err := e.redisClient.Get(ctx, cacheKey, employee)
-	if err == nil {
-		return employee, nil
+	ctx, span := tracer.Start(ctx, "EmployeeService.GetEmployee")
+	defer span.End()
+
+	// The cache holds the complete record, sensitive fields are redacted per caller when responding.
+	employee, err := cache.GetOrLoad(ctx, e.cache, employeeCacheKey(id), func(ctx context.Context) (*model.Employee, error) {
+		employee, err := e.repo.GetByID(ctx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cache.ErrNotFound
+		}
+		return employee, err
+	})
+	if errors.Is(err, cache.ErrNotFound) {
+		return nil, fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, id)
+	}
+	if err != nil {
+		return nil, err
 	}
+	return employee, nil
+}
+
+func (e employeeService) TerminateEmployee(ctx context.Context, id uint, terminationDate time.Time, reason string) (*model.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.TerminateEmployee")
+	defer span.End()
 
-	employee, err = e.repo.GetByID(id)
+	existed, err := e.repo.GetByID(ctx, id)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("employee not found by id: %d", id)
+		return nil, fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, id)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if existed.EmploymentStatus == model.EmploymentStatusTerminated {
+		return nil, ErrEmployeeAlreadyTerminated
+	}
+	if terminationDate.Before(existed.OnboardDate) {
+		return nil, ErrTerminationBeforeOnboard
+	}
+	// the status changes right away, so terminations are recorded once they take effect
+	if today := time.Now().UTC().Truncate(24 * time.Hour); !terminationDate.Before(today.AddDate(0, 0, 1)) {
+		return nil, ErrFutureTermination
+	}
 
-	err = e.redisClient.Set(ctx, cacheKey, employee, 1*time.Hour)
+	before := *existed
+	existed.EmploymentStatus = model.EmploymentStatusTerminated
+	existed.TerminationDate = &terminationDate
+	existed.TerminationReason = reason
+	var terminated *model.Employee
+	err = e.transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if terminated, err = e.update(ctx, &before, existed, model.AuditOperationTerminate); err != nil {
+			return err
+		}
+		return e.dayOffService.CloseAfterTermination(ctx, terminated)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("Failed to cache employee: %s", err.Error())
+		return nil, err
 	}
-	return employee, nil
+	e.invalidate(ctx, id)
+
+	return terminated, nil
 }
 
-func (e employeeService) DeleteEmployee(ctx context.Context, id uint) error {
-	return e.repo.Delete(id)
+func (e employeeService) RehireEmployee(ctx context.Context, id uint, onboardDate time.Time) (*model.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.RehireEmployee")
+	defer span.End()
+
+	existed, err := e.repo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if existed.EmploymentStatus != model.EmploymentStatusTerminated {
+		return nil, ErrEmployeeNotTerminated
+	}
+	if existed.TerminationDate != nil && !onboardDate.After(*existed.TerminationDate) {
+		return nil, ErrRehireBeforeTermination
+	}
+
+	// Seniority restarts with the new onboard date for the entitlements accrued from now on. An accrual
+	// of the current year is deliberately not recomputed: it was granted for the whole year, and the
+	// leave the earlier employment took stays debited against it.
+	before := *existed
+	existed.EmploymentStatus = model.EmploymentStatusActive
+	existed.OnboardDate = onboardDate
+	existed.TerminationDate = nil
+	existed.TerminationReason = ""
+	return e.saveLifecycleChange(ctx, &before, existed, model.AuditOperationRehire)
+}
+
+func (e employeeService) saveLifecycleChange(ctx context.Context, before, employee *model.Employee, operation string) (*model.Employee, error) {
+	updated, err := e.update(ctx, before, employee, operation)
+	if err != nil {
+		return nil, err
+	}
+	e.invalidate(ctx, employee.ID)
+
+	return updated, nil
+}
+
+// update saves the employee and audits the change in one transaction, returning the saved employee.
+func (e employeeService) update(ctx context.Context, before, employee *model.Employee, operation string) (*model.Employee, error) {
+	var updated *model.Employee
+	err := e.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := e.repo.Update(ctx, employee); err != nil {
+			return err
+		}
+		var err error
+		if updated, err = e.repo.GetByID(ctx, employee.ID); err != nil {
+			return err
+		}
+		return e.auditService.Record(ctx, model.AuditEntityEmployee, updated.ID, operation, before, updated)
+	})
+	return updated, err
 }
 
 func (e employeeService) UpdateEmployee(ctx context.Context, employee *model.Employee) (*model.Employee, error) {
-	existed, err := e.repo.GetByID(employee.ID)
+	ctx, span := tracer.Start(ctx, "EmployeeService.UpdateEmployee")
+	defer span.End()
+
+	existed, err := e.repo.GetByID(ctx, employee.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, employee.ID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	if existed.Email != employee.Email {
-		if _, err := e.repo.GetByEmail(employee.Email); err == nil {
-			return nil, fmt.Errorf("employee email already exists: %s", employee.Email)
+		if _, err := e.repo.GetByEmail(ctx, employee.Email); err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrEmailAlreadyExists, employee.Email)
 		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
 	}
+	if err = e.validateManager(ctx, existed.ID, employee.ManagerID); err != nil {
+		return nil, err
+	}
+	before := *existed
 	existed.Email = employee.Email
 	existed.PhoneNumber = employee.PhoneNumber
 	existed.Address = employee.Address
 	existed.Department = employee.Department
 	existed.Salary = employee.Salary
+	existed.ManagerID = employee.ManagerID
+	existed.Region = employee.Region
 
-	if err := e.repo.Update(existed); err != nil {
-		return nil, err
-	}
-
-	updated, err := e.repo.GetByID(existed.ID)
+	updated, err := e.update(ctx, &before, existed, model.AuditOperationUpdate)
 	if err != nil {
 		return nil, err
 	}
-	cacheKey := fmt.Sprintf("employee:%d", employee.ID)
-	_ = e.redisClient.Delete(ctx, cacheKey) // todo: make update in transaction and rollback if delete cache failed
+	e.invalidate(ctx, employee.ID)
 
 	return updated, nil
 }
 
 func (e employeeService) ListEmployees(ctx context.Context, params *model.ListParams) (*PaginatedResult[model.Employee], error) {
-	results, totalCount, err := e.repo.List(params)
+	ctx, span := tracer.Start(ctx, "EmployeeService.ListEmployees")
+	defer span.End()
+
+	results, totalCount, err := e.repo.List(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -133,3 +308,113 @@ func (e employeeService) ListEmployees(ctx context.Context, params *model.ListPa
 		PageSize:   params.PageSize,
 	}, nil
 }
+
+func (e employeeService) getManager(ctx context.Context, managerID uint) (*model.Employee, error) {
+	manager, err := e.repo.GetByID(ctx, managerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w by id: %d", ErrManagerNotFound, managerID)
+	}
+	return manager, err
+}
+
+// validateManager makes sure the new manager exists and that the employee does not
+// end up reporting to themselves, directly or through the management chain.
+func (e employeeService) validateManager(ctx context.Context, employeeID uint, managerID *uint) error {
+	if managerID == nil {
+		return nil
+	}
+	if *managerID == employeeID {
+		return ErrManagerCycle
+	}
+
+	manager, err := e.getManager(ctx, *managerID)
+	if err != nil {
+		return err
+	}
+	chain, err := e.managementChain(ctx, manager)
+	if err != nil {
+		return err
+	}
+	for _, m := range chain {
+		if m.ID == employeeID {
+			return ErrManagerCycle
+		}
+	}
+	return nil
+}
+
+// managementChain walks up from the employee's direct manager to the top of the organization.
+func (e employeeService) managementChain(ctx context.Context, employee *model.Employee) ([]model.Employee, error) {
+	var chain []model.Employee
+	visited := map[uint]bool{employee.ID: true}
+	for current := employee; current.ManagerID != nil; {
+		if visited[*current.ManagerID] {
+			// stop on inconsistent data instead of looping forever
+			break
+		}
+		manager, err := e.getManager(ctx, *current.ManagerID)
+		if err != nil {
+			return nil, err
+		}
+		visited[manager.ID] = true
+		chain = append(chain, *manager)
+		current = manager
+	}
+	return chain, nil
+}
+
+func (e employeeService) ListDirectReports(ctx context.Context, id uint, includeTerminated bool) ([]model.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.ListDirectReports")
+	defer span.End()
+
+	if _, err := e.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, id)
+		}
+		return nil, err
+	}
+	return e.repo.ListByManagerID(ctx, id, includeTerminated)
+}
+
+func (e employeeService) GetManagementChain(ctx context.Context, id uint) ([]model.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.GetManagementChain")
+	defer span.End()
+
+	employee, err := e.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, id)
+		}
+		return nil, err
+	}
+	return e.managementChain(ctx, employee)
+}
+
+func (e employeeService) GetOrgChart(ctx context.Context, department string, includeTerminated bool) ([]*OrgChartNode, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.GetOrgChart")
+	defer span.End()
+
+	employees, err := e.repo.ListByDepartment(ctx, department, includeTerminated)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[uint]*OrgChartNode, len(employees))
+	for _, employee := range employees {
+		nodes[employee.ID] = &OrgChartNode{Employee: employee}
+	}
+
+	// Employees without a manager in the department are the roots of the chart.
+	var roots []*OrgChartNode
+	for _, employee := range employees {
+		node := nodes[employee.ID]
+		if employee.ManagerID != nil {
+			if manager, ok := nodes[*employee.ManagerID]; ok {
+				manager.Reports = append(manager.Reports, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots, nil
+}
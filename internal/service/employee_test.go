@@ -4,13 +4,13 @@ import (
 	"context"
 	"log"
 	"testing"
+	"time"
 
-	"github.com/go-redis/redismock/v9"
 	"github.com/ory/dockertest/v3"
-	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
+	"github.com/joremysh/fliqt/internal/model"
 	"github.com/joremysh/fliqt/internal/repository"
 	"github.com/joremysh/fliqt/pkg/cache"
 	testingx "github.com/joremysh/fliqt/pkg/testing"
@@ -21,7 +21,6 @@ var (
 	err      error
 	pool     *dockertest.Pool
 	resource *dockertest.Resource
-	client   *redis.Client
 	repo     repository.Employee
 )
 
@@ -34,7 +33,6 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-	client, _ = redismock.NewClientMock()
 
 	m.Run()
 
@@ -51,7 +49,7 @@ func TestEmployeeService_CreateEmployee(t *testing.T) {
 	})
 	repo = repository.NewEmployeeRepo(tx)
 
-	svc := NewEmployeeService(repo, &cache.RedisClient{Client: client})
+	svc := NewEmployeeService(repo, repository.NewTransactor(tx), cache.NewMemory(100, time.Minute), NewAuditService(repository.NewAuditRepo(tx)), newDayOffService(tx))
 	employee := repository.MockEmployee()
 	ctx := context.Background()
 	created, err := svc.CreateEmployee(ctx, employee)
@@ -60,3 +58,132 @@ func TestEmployeeService_CreateEmployee(t *testing.T) {
 	require.Equal(t, employee.Email, created.Email)
 	require.Equal(t, employee.PhoneNumber, created.PhoneNumber)
 }
+
+func TestEmployeeService_UpdateEmployee_ManagerCycle(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	repo = repository.NewEmployeeRepo(tx)
+	svc := NewEmployeeService(repo, repository.NewTransactor(tx), cache.NewMemory(100, time.Minute), NewAuditService(repository.NewAuditRepo(tx)), newDayOffService(tx))
+	ctx := context.Background()
+
+	top, err := svc.CreateEmployee(ctx, repository.MockEmployee())
+	require.NoError(t, err)
+	middle := repository.MockEmployee()
+	middle.ManagerID = &top.ID
+	middle, err = svc.CreateEmployee(ctx, middle)
+	require.NoError(t, err)
+	bottom := repository.MockEmployee()
+	bottom.ManagerID = &middle.ID
+	bottom, err = svc.CreateEmployee(ctx, bottom)
+	require.NoError(t, err)
+
+	chain, err := svc.GetManagementChain(ctx, bottom.ID)
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	require.Equal(t, middle.ID, chain[0].ID)
+	require.Equal(t, top.ID, chain[1].ID)
+
+	top.ManagerID = &bottom.ID
+	_, err = svc.UpdateEmployee(ctx, top)
+	require.ErrorIs(t, err, ErrManagerCycle)
+
+	reports, err := svc.ListDirectReports(ctx, top.ID, false)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, middle.ID, reports[0].ID)
+}
+
+func TestEmployeeService_TerminateAndRehire(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	repo = repository.NewEmployeeRepo(tx)
+	dayOffSvc := newDayOffService(tx)
+	svc := NewEmployeeService(repo, repository.NewTransactor(tx), cache.NewMemory(100, time.Minute), NewAuditService(repository.NewAuditRepo(tx)), dayOffSvc)
+	ctx := context.Background()
+
+	manager, err := svc.CreateEmployee(ctx, repository.MockEmployee())
+	require.NoError(t, err)
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-3, 0, 0)
+	employee.ManagerID = &manager.ID
+	employee.Department = manager.Department
+	created, err := svc.CreateEmployee(ctx, employee)
+	require.NoError(t, err)
+	require.Equal(t, model.EmploymentStatusActive, created.EmploymentStatus)
+
+	dayOff := func(start time.Time) *model.DayOffRecord {
+		submitted, err := dayOffSvc.SubmitDayOff(ctx, &model.DayOffRecord{
+			EmployeeID: created.ID,
+			DayOffType: "PTO",
+			Reason:     "vacation",
+			StartTime:  start,
+			EndTime:    start.Add(9 * time.Hour),
+		})
+		require.NoError(t, err)
+		return submitted
+	}
+	taken := dayOff(nextMonday().AddDate(0, 0, -14))
+	planned := dayOff(nextMonday())
+
+	lastDay := time.Now().UTC().Truncate(24 * time.Hour)
+	_, err = svc.TerminateEmployee(ctx, created.ID, lastDay.AddDate(0, 0, 1), "resigned")
+	require.ErrorIs(t, err, ErrFutureTermination)
+	terminated, err := svc.TerminateEmployee(ctx, created.ID, lastDay, "resigned")
+	require.NoError(t, err)
+	require.Equal(t, model.EmploymentStatusTerminated, terminated.EmploymentStatus)
+	require.Equal(t, "resigned", terminated.TerminationReason)
+
+	// the leave after the last day is given up, the leave taken before it is kept
+	taken, err = dayOffSvc.GetDayOff(ctx, taken.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.DayOffStatusPending, taken.Status)
+	planned, err = dayOffSvc.GetDayOff(ctx, planned.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.DayOffStatusWithdrawn, planned.Status)
+	require.Equal(t, "employment terminated", planned.CancellationReason)
+
+	// people who left are no longer reports, unless asked for
+	reports, err := svc.ListDirectReports(ctx, manager.ID, false)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+	reports, err = svc.ListDirectReports(ctx, manager.ID, true)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	chart, err := svc.GetOrgChart(ctx, manager.Department, false)
+	require.NoError(t, err)
+	for _, root := range chart {
+		require.NotEqual(t, created.ID, root.Employee.ID)
+		require.Empty(t, root.Reports)
+	}
+
+	_, err = svc.TerminateEmployee(ctx, created.ID, lastDay, "again")
+	require.ErrorIs(t, err, ErrEmployeeAlreadyTerminated)
+
+	result, err := svc.ListEmployees(ctx, &model.ListParams{Page: 1, PageSize: 100, Filters: map[string]string{"email": created.Email}})
+	require.NoError(t, err)
+	require.Empty(t, result.Data)
+	result, err = svc.ListEmployees(ctx, &model.ListParams{Page: 1, PageSize: 100, Filters: map[string]string{"email": created.Email}, IncludeTerminated: true})
+	require.NoError(t, err)
+	require.Len(t, result.Data, 1)
+
+	_, err = dayOffSvc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: created.ID,
+		DayOffType: "PTO",
+		Reason:     "farewell trip",
+		StartTime:  lastDay.AddDate(0, 0, -3),
+		EndTime:    lastDay.AddDate(0, 0, 1),
+	})
+	require.ErrorIs(t, err, ErrEmployeeTerminated)
+
+	_, err = svc.RehireEmployee(ctx, created.ID, lastDay)
+	require.ErrorIs(t, err, ErrRehireBeforeTermination)
+
+	rehired, err := svc.RehireEmployee(ctx, created.ID, lastDay.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	require.Equal(t, model.EmploymentStatusActive, rehired.EmploymentStatus)
+	require.Nil(t, rehired.TerminationDate)
+}
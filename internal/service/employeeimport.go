@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/auth"
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/pkg/cache"
+)
+
+// MaxImportRows bounds the number of rows of a single import.
+const MaxImportRows = 50000
+
+const (
+	importBatchSize           = 500
+	importBackgroundThreshold = 1000
+	// maxImportRowErrors bounds the row errors kept with an import, the failed rows are still all counted.
+	maxImportRowErrors = 1000
+)
+
+type EmployeeImportService interface {
+	// ImportEmployees validates the rows and, unless dryRun is set, creates the valid ones. Imports
+	// with more rows than run in a request are returned while still running in the background.
+	ImportEmployees(ctx context.Context, rows []ImportRow, dryRun bool) (*model.EmployeeImport, error)
+	GetImport(ctx context.Context, id uint) (*model.EmployeeImport, error)
+	// Shutdown stops the background imports after their current batch and waits for them until ctx is done.
+	Shutdown(ctx context.Context) error
+}
+
+// ImportRow is an employee read from an import file. Errors holds the problems found while
+// reading the row, such as a missing field; Employee is nil when the row could not be read.
+type ImportRow struct {
+	Employee *model.Employee
+	Errors   []ImportRowError
+}
+
+// ImportRowError is a problem with a row of an import. Rows are numbered from 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+var (
+	ErrImportEmpty            = newValidationError("import_empty", "", "the import has no rows")
+	ErrImportTooLarge         = newValidationError("import_too_large", "", fmt.Sprintf("an import has at most %d rows", MaxImportRows))
+	ErrEmployeeImportNotFound = newNotFoundError("employee_import_not_found", "employee import not found")
+	ErrDuplicateImportEmail   = newValidationError("duplicate_email", "email", "email is used by more than one row")
+)
+
+// errImportInterrupted stops background imports when the server shuts down.
+var errImportInterrupted = errors.New("interrupted by a server shutdown")
+
+// employeeImportService writes every batch in a transaction of its own.
+type employeeImportService struct {
+	repo                repository.EmployeeImport
+	transactor          repository.Transactor
+	employeeRepo        repository.Employee
+	auditService        AuditService
+	cache               *cache.Aside
+	batchSize           int
+	backgroundThreshold int
+
+	stopping chan struct{}
+	stopOnce sync.Once
+	running  sync.WaitGroup
+}
+
+func NewEmployeeImportService(repo repository.EmployeeImport, transactor repository.Transactor, employeeRepo repository.Employee, auditService AuditService, employeeCache cache.Cache) EmployeeImportService {
+	return &employeeImportService{
+		repo:                repo,
+		transactor:          transactor,
+		employeeRepo:        employeeRepo,
+		auditService:        auditService,
+		cache:               cache.NewAside(employeeCache, employeeCacheOptions),
+		batchSize:           importBatchSize,
+		backgroundThreshold: importBackgroundThreshold,
+		stopping:            make(chan struct{}),
+	}
+}
+
+func (s *employeeImportService) ImportEmployees(ctx context.Context, rows []ImportRow, dryRun bool) (*model.EmployeeImport, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeImportService.ImportEmployees")
+	defer span.End()
+
+	if len(rows) == 0 {
+		return nil, ErrImportEmpty
+	}
+	if len(rows) > MaxImportRows {
+		return nil, ErrImportTooLarge
+	}
+
+	employeeImport := &model.EmployeeImport{
+		Status:    model.ImportStatusRunning,
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Errors:    "[]",
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		employeeImport.CreatedBy = &principal.EmployeeID
+	}
+	if err := s.repo.Create(ctx, employeeImport); err != nil {
+		return nil, err
+	}
+
+	if len(rows) <= s.backgroundThreshold {
+		if err := s.run(ctx, employeeImport, rows); err != nil {
+			return nil, err
+		}
+		return employeeImport, nil
+	}
+
+	// the import outlives the request, it keeps the caller for the audit trail
+	started := *employeeImport
+	ctx = context.WithoutCancel(ctx)
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		if err := s.run(ctx, employeeImport, rows); err != nil {
+			slog.ErrorContext(ctx, "employee import failed", "import", employeeImport.ID, "error", err)
+		}
+	}()
+	return &started, nil
+}
+
+func (s *employeeImportService) GetImport(ctx context.Context, id uint) (*model.EmployeeImport, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeImportService.GetImport")
+	defer span.End()
+
+	employeeImport, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w by id: %d", ErrEmployeeImportNotFound, id)
+	}
+	return employeeImport, err
+}
+
+func (s *employeeImportService) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stopping)
+	})
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run validates and writes the rows batch by batch, recording the progress after every batch.
+// Rows committed before a failure stay imported.
+func (s *employeeImportService) run(ctx context.Context, employeeImport *model.EmployeeImport, rows []ImportRow) error {
+	var rowErrors []ImportRowError
+	// the first row of every email, emails are compared like the database does, ignoring case
+	seen := make(map[string]int, len(rows))
+	for offset := 0; offset < len(rows); offset += s.batchSize {
+		select {
+		case <-s.stopping:
+			return s.finish(ctx, employeeImport, rowErrors, errImportInterrupted)
+		default:
+		}
+
+		batch := rows[offset:min(offset+s.batchSize, len(rows))]
+		valid, batchErrors, err := s.validate(ctx, batch, offset, seen)
+		if err == nil && !employeeImport.DryRun && len(valid) > 0 {
+			err = s.create(ctx, valid)
+		}
+		if err != nil {
+			return s.finish(ctx, employeeImport, rowErrors, err)
+		}
+
+		rowErrors = append(rowErrors, batchErrors...)
+		employeeImport.ProcessedRows += len(batch)
+		employeeImport.ImportedRows += len(valid)
+		employeeImport.FailedRows += len(batch) - len(valid)
+		if employeeImport.ProcessedRows < len(rows) {
+			if err = s.save(ctx, employeeImport, rowErrors); err != nil {
+				return s.finish(ctx, employeeImport, rowErrors, err)
+			}
+		}
+	}
+	return s.finish(ctx, employeeImport, rowErrors, nil)
+}
+
+// validate applies the rules of CreateEmployee to a batch of rows starting at offset, and
+// returns the employees of the rows without errors.
+func (s *employeeImportService) validate(ctx context.Context, batch []ImportRow, offset int, seen map[string]int) ([]*model.Employee, []ImportRowError, error) {
+	var emails []string
+	var managerIDs []uint
+	for _, row := range batch {
+		if row.Employee == nil {
+			continue
+		}
+		emails = append(emails, row.Employee.Email)
+		if row.Employee.ManagerID != nil {
+			managerIDs = append(managerIDs, *row.Employee.ManagerID)
+		}
+	}
+
+	existingEmails, err := s.employeeRepo.ExistingEmails(ctx, emails)
+	if err != nil {
+		return nil, nil, err
+	}
+	taken := make(map[string]bool, len(existingEmails))
+	for _, email := range existingEmails {
+		taken[strings.ToLower(email)] = true
+	}
+	existingIDs, err := s.employeeRepo.ExistingIDs(ctx, managerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	managers := make(map[uint]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		managers[id] = true
+	}
+
+	var valid []*model.Employee
+	var rowErrors []ImportRowError
+	for i, row := range batch {
+		number := offset + i + 1
+		errs := make([]ImportRowError, 0, len(row.Errors))
+		for _, rowErr := range row.Errors {
+			rowErr.Row = number
+			errs = append(errs, rowErr)
+		}
+		if employee := row.Employee; employee != nil {
+			email := strings.ToLower(employee.Email)
+			if first, ok := seen[email]; ok {
+				errs = append(errs, ImportRowError{
+					Row:     number,
+					Field:   ErrDuplicateImportEmail.Field,
+					Code:    ErrDuplicateImportEmail.Code,
+					Message: fmt.Sprintf("email is already used on row %d", first),
+				})
+			} else {
+				seen[email] = number
+				if taken[email] {
+					errs = append(errs, newImportRowError(number, ErrEmailAlreadyExists))
+				}
+			}
+			if employee.ManagerID != nil && !managers[*employee.ManagerID] {
+				errs = append(errs, newImportRowError(number, ErrManagerNotFound))
+			}
+		}
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		valid = append(valid, row.Employee)
+	}
+	return valid, rowErrors, nil
+}
+
+func newImportRowError(row int, err *Error) ImportRowError {
+	return ImportRowError{Row: row, Field: err.Field, Code: err.Code, Message: err.Message}
+}
+
+// create inserts a batch of employees and their audit events in one transaction.
+func (s *employeeImportService) create(ctx context.Context, employees []*model.Employee) error {
+	for _, employee := range employees {
+		employee.EmploymentStatus = model.EmploymentStatusActive
+	}
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.employeeRepo.CreateBatch(ctx, employees); err != nil {
+			return err
+		}
+		for _, employee := range employees {
+			if err := s.auditService.Record(ctx, model.AuditEntityEmployee, employee.ID, model.AuditOperationCreate, nil, employee); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the ids may have been looked up, and cached as missing, before they existed
+	keys := make([]string, len(employees))
+	for i, employee := range employees {
+		keys[i] = employeeCacheKey(employee.ID)
+	}
+	if err = s.cache.Invalidate(ctx, keys...); err != nil {
+		slog.WarnContext(ctx, "failed to invalidate the cached employees", "employees", len(keys), "error", err)
+	}
+	return nil
+}
+
+func (s *employeeImportService) save(ctx context.Context, employeeImport *model.EmployeeImport, rowErrors []ImportRowError) error {
+	errorsJSON, err := json.Marshal(rowErrors[:min(len(rowErrors), maxImportRowErrors)])
+	if err != nil {
+		return err
+	}
+	if rowErrors == nil {
+		errorsJSON = []byte("[]")
+	}
+	employeeImport.Errors = string(errorsJSON)
+	return s.repo.Update(ctx, employeeImport)
+}
+
+// finish records the outcome of an import. The reason of a failure is only described in general
+// terms to the client, it is returned for the logs.
+func (s *employeeImportService) finish(ctx context.Context, employeeImport *model.EmployeeImport, rowErrors []ImportRowError, cause error) error {
+	now := time.Now()
+	employeeImport.FinishedAt = &now
+	employeeImport.Status = model.ImportStatusSucceeded
+	if cause != nil {
+		employeeImport.Status = model.ImportStatusFailed
+		employeeImport.Error = "internal error"
+		if errors.Is(cause, errImportInterrupted) {
+			employeeImport.Error = cause.Error()
+		}
+	}
+	// the outcome is recorded even when the request that ran the import went away
+	if err := s.save(context.WithoutCancel(ctx), employeeImport, rowErrors); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/pkg/cache"
+)
+
+// newEmployeeImportService builds the employee import service over tx, with an in-memory cache.
+func newEmployeeImportService(tx *gorm.DB) EmployeeImportService {
+	return NewEmployeeImportService(repository.NewEmployeeImportRepo(tx), repository.NewTransactor(tx), repository.NewEmployeeRepo(tx),
+		NewAuditService(repository.NewAuditRepo(tx)), cache.NewMemory(100, time.Minute))
+}
+
+func TestEmployeeImportService_ImportEmployees(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	svc := newEmployeeImportService(tx)
+	ctx := context.Background()
+
+	existing := repository.MockEmployee()
+	require.NoError(t, employeeRepo.Create(ctx, existing))
+	missingManager := existing.ID + 1000
+
+	rows := make([]ImportRow, 6)
+	for i := range rows {
+		rows[i] = ImportRow{Employee: repository.MockEmployee()}
+	}
+	rows[1].Employee.Email = strings.ToUpper(existing.Email)
+	rows[2].Employee.Email = strings.ToUpper(rows[0].Employee.Email)
+	rows[3].Employee.ManagerID = &missingManager
+	rows[4] = ImportRow{Errors: []ImportRowError{{Field: "name", Code: "invalid_request", Message: "property \"name\" is missing"}}}
+	rows[5].Employee.ManagerID = &existing.ID
+
+	dryRun, err := svc.ImportEmployees(ctx, rows, true)
+	require.NoError(t, err)
+	require.Equal(t, model.ImportStatusSucceeded, dryRun.Status)
+	require.Equal(t, 2, dryRun.ImportedRows)
+	require.Equal(t, 4, dryRun.FailedRows)
+	_, err = employeeRepo.GetByEmail(ctx, rows[0].Employee.Email)
+	require.Error(t, err)
+
+	var rowErrors []ImportRowError
+	require.NoError(t, json.Unmarshal([]byte(dryRun.Errors), &rowErrors))
+	require.Equal(t, []ImportRowError{
+		{Row: 2, Field: "email", Code: ErrEmailAlreadyExists.Code, Message: ErrEmailAlreadyExists.Message},
+		{Row: 3, Field: "email", Code: ErrDuplicateImportEmail.Code, Message: "email is already used on row 1"},
+		{Row: 4, Field: "managerID", Code: ErrManagerNotFound.Code, Message: ErrManagerNotFound.Message},
+		{Row: 5, Field: "name", Code: "invalid_request", Message: "property \"name\" is missing"},
+	}, rowErrors)
+
+	imported, err := svc.ImportEmployees(ctx, rows, false)
+	require.NoError(t, err)
+	require.Equal(t, 2, imported.ImportedRows)
+	created, err := employeeRepo.GetByEmail(ctx, rows[5].Employee.Email)
+	require.NoError(t, err)
+	require.Equal(t, model.EmploymentStatusActive, created.EmploymentStatus)
+	require.Equal(t, existing.ID, *created.ManagerID)
+
+	events, _, err := repository.NewAuditRepo(tx).List(ctx, &model.AuditEventQuery{
+		Page: 1, PageSize: 10, EntityType: model.AuditEntityEmployee, EntityID: &created.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	_, err = svc.ImportEmployees(ctx, nil, false)
+	require.ErrorIs(t, err, ErrImportEmpty)
+}
+
+func TestEmployeeImportService_Background(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	svc := newEmployeeImportService(tx).(*employeeImportService)
+	svc.batchSize = 2
+	svc.backgroundThreshold = 3
+	ctx := context.Background()
+
+	rows := make([]ImportRow, 5)
+	for i := range rows {
+		rows[i] = ImportRow{Employee: repository.MockEmployee()}
+	}
+	started, err := svc.ImportEmployees(ctx, rows, false)
+	require.NoError(t, err)
+	require.Equal(t, model.ImportStatusRunning, started.Status)
+	require.Equal(t, 5, started.TotalRows)
+
+	svc.running.Wait()
+	finished, err := svc.GetImport(ctx, started.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.ImportStatusSucceeded, finished.Status)
+	require.Equal(t, 5, finished.ProcessedRows)
+	require.Equal(t, 5, finished.ImportedRows)
+	require.NotNil(t, finished.FinishedAt)
+
+	_, err = svc.GetImport(ctx, started.ID+1)
+	require.ErrorIs(t, err, ErrEmployeeImportNotFound)
+}
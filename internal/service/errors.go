@@ -0,0 +1,40 @@
+package service
+
+// ErrorKind classifies domain errors so that transports can map them without string matching.
+type ErrorKind int
+
+const (
+	KindNotFound ErrorKind = iota + 1
+	KindConflict
+	KindValidation
+	KindForbidden
+)
+
+// Error is a domain error with a stable machine-readable code. Field is set when
+// the error is caused by a single input field.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Field   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newNotFoundError(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func newConflictError(code, field, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Field: field, Message: message}
+}
+
+func newValidationError(code, field, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Field: field, Message: message}
+}
+
+func newForbiddenError(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
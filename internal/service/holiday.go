@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+)
+
+// MaxHolidayImport bounds the number of dates of a single holiday import.
+const MaxHolidayImport = 1000
+
+type HolidayService interface {
+	ListHolidays(ctx context.Context, query *model.HolidayQuery) (*PaginatedResult[model.Holiday], error)
+	GetHoliday(ctx context.Context, id uint) (*model.Holiday, error)
+	CreateHoliday(ctx context.Context, holiday *model.Holiday) (*model.Holiday, error)
+	UpdateHoliday(ctx context.Context, holiday *model.Holiday) (*model.Holiday, error)
+	DeleteHoliday(ctx context.Context, id uint) error
+	// ImportHolidays creates the holidays of a region, or renames those that exist on the same dates,
+	// all at once. Holidays missing from the import are kept.
+	ImportHolidays(ctx context.Context, region string, holidays []model.Holiday) (*HolidayImport, error)
+}
+
+// HolidayImport counts what an import did with its dates.
+type HolidayImport struct {
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+var (
+	ErrHolidayNotFound       = newNotFoundError("holiday_not_found", "holiday not found")
+	ErrHolidayExists         = newConflictError("holiday_exists", "date", "the region already has a holiday on this date")
+	ErrInvalidHolidayRange   = newValidationError("invalid_date_range", "to", "to must be after from")
+	ErrHolidayImportEmpty    = newValidationError("import_empty", "", "the calendar has no events")
+	ErrHolidayImportTooLarge = newValidationError("import_too_large", "", fmt.Sprintf("an import has at most %d dates", MaxHolidayImport))
+)
+
+type holidayService struct {
+	repo         repository.Holiday
+	transactor   repository.Transactor
+	auditService AuditService
+}
+
+func NewHolidayService(repo repository.Holiday, transactor repository.Transactor, auditService AuditService) HolidayService {
+	return &holidayService{
+		repo:         repo,
+		transactor:   transactor,
+		auditService: auditService,
+	}
+}
+
+func (s *holidayService) ListHolidays(ctx context.Context, query *model.HolidayQuery) (*PaginatedResult[model.Holiday], error) {
+	ctx, span := tracer.Start(ctx, "HolidayService.ListHolidays")
+	defer span.End()
+
+	if query.From != nil && query.To != nil && !query.To.After(*query.From) {
+		return nil, ErrInvalidHolidayRange
+	}
+	holidays, total, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &PaginatedResult[model.Holiday]{
+		Data:       holidays,
+		TotalCount: total,
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+	}, nil
+}
+
+func (s *holidayService) GetHoliday(ctx context.Context, id uint) (*model.Holiday, error) {
+	ctx, span := tracer.Start(ctx, "HolidayService.GetHoliday")
+	defer span.End()
+
+	holiday, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w by id: %d", ErrHolidayNotFound, id)
+	}
+	return holiday, err
+}
+
+func (s *holidayService) CreateHoliday(ctx context.Context, holiday *model.Holiday) (*model.Holiday, error) {
+	ctx, span := tracer.Start(ctx, "HolidayService.CreateHoliday")
+	defer span.End()
+
+	if err := s.ensureFree(ctx, holiday, 0); err != nil {
+		return nil, err
+	}
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, holiday); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, model.AuditEntityHoliday, holiday.ID, model.AuditOperationCreate, nil, holiday)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, holiday.ID)
+}
+
+func (s *holidayService) UpdateHoliday(ctx context.Context, holiday *model.Holiday) (*model.Holiday, error) {
+	ctx, span := tracer.Start(ctx, "HolidayService.UpdateHoliday")
+	defer span.End()
+
+	existed, err := s.GetHoliday(ctx, holiday.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.ensureFree(ctx, holiday, holiday.ID); err != nil {
+		return nil, err
+	}
+
+	before := *existed
+	existed.Region = holiday.Region
+	existed.Date = holiday.Date
+	existed.Name = holiday.Name
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, existed); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, model.AuditEntityHoliday, existed.ID, model.AuditOperationUpdate, &before, existed)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, existed.ID)
+}
+
+func (s *holidayService) DeleteHoliday(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "HolidayService.DeleteHoliday")
+	defer span.End()
+
+	existed, err := s.GetHoliday(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, model.AuditEntityHoliday, id, model.AuditOperationDelete, existed, nil)
+	})
+}
+
+func (s *holidayService) ImportHolidays(ctx context.Context, region string, holidays []model.Holiday) (*HolidayImport, error) {
+	ctx, span := tracer.Start(ctx, "HolidayService.ImportHolidays")
+	defer span.End()
+
+	if len(holidays) == 0 {
+		return nil, ErrHolidayImportEmpty
+	}
+	if len(holidays) > MaxHolidayImport {
+		return nil, ErrHolidayImportTooLarge
+	}
+
+	result := &HolidayImport{}
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		// a calendar may list two events on a day, the first one names it
+		seen := make(map[string]bool, len(holidays))
+		for _, holiday := range holidays {
+			holiday.Region = region
+			key := holiday.Date.Format(time.DateOnly)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			existed, err := s.repo.GetByDate(ctx, region, holiday.Date)
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err = s.repo.Create(ctx, &holiday); err != nil {
+					return err
+				}
+				result.Created++
+				err = s.auditService.Record(ctx, model.AuditEntityHoliday, holiday.ID, model.AuditOperationCreate, nil, &holiday)
+			case err != nil:
+				return err
+			case existed.Name == holiday.Name:
+				result.Unchanged++
+			default:
+				before := *existed
+				existed.Name = holiday.Name
+				if err = s.repo.Update(ctx, existed); err != nil {
+					return err
+				}
+				result.Updated++
+				err = s.auditService.Record(ctx, model.AuditEntityHoliday, existed.ID, model.AuditOperationUpdate, &before, existed)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ensureFree checks that the region has no holiday on the date other than the one with the given id.
+func (s *holidayService) ensureFree(ctx context.Context, holiday *model.Holiday, id uint) error {
+	existed, err := s.repo.GetByDate(ctx, holiday.Region, holiday.Date)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existed.ID != id {
+		return fmt.Errorf("%w: %s %s", ErrHolidayExists, holiday.Region, holiday.Date.Format(time.DateOnly))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/internal/worktime"
+)
+
+func TestHolidayService_ImportHolidays(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	svc := NewHolidayService(repository.NewHolidayRepo(tx), repository.NewTransactor(tx), NewAuditService(repository.NewAuditRepo(tx)))
+	ctx := context.Background()
+
+	day := time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC)
+	created, err := svc.CreateHoliday(ctx, &model.Holiday{Region: "TW", Date: day, Name: "New Year"})
+	require.NoError(t, err)
+	_, err = svc.CreateHoliday(ctx, &model.Holiday{Region: "TW", Date: day, Name: "Again"})
+	require.ErrorIs(t, err, ErrHolidayExists)
+	// the same date in another region is another holiday
+	_, err = svc.CreateHoliday(ctx, &model.Holiday{Region: "JP", Date: day, Name: "Shogatsu"})
+	require.NoError(t, err)
+
+	result, err := svc.ImportHolidays(ctx, "TW", []model.Holiday{
+		{Date: day, Name: "New Year"},
+		{Date: day.AddDate(0, 1, 0), Name: "Lunar New Year"},
+		{Date: day.AddDate(0, 1, 0), Name: "Duplicate"},
+		{Date: day.AddDate(0, 1, 1), Name: "Lunar New Year"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &HolidayImport{Created: 2, Unchanged: 1}, result)
+
+	result, err = svc.ImportHolidays(ctx, "TW", []model.Holiday{{Date: day, Name: "Founding Day"}})
+	require.NoError(t, err)
+	require.Equal(t, &HolidayImport{Updated: 1}, result)
+	renamed, err := svc.GetHoliday(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Founding Day", renamed.Name)
+
+	to := day.AddDate(1, 0, 0)
+	listed, err := svc.ListHolidays(ctx, &model.HolidayQuery{Page: 1, PageSize: 10, Region: "TW", From: &day, To: &to})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, listed.TotalCount)
+
+	_, err = svc.ImportHolidays(ctx, "TW", nil)
+	require.ErrorIs(t, err, ErrHolidayImportEmpty)
+
+	require.NoError(t, svc.DeleteHoliday(ctx, created.ID))
+	_, err = svc.GetHoliday(ctx, created.ID)
+	require.ErrorIs(t, err, ErrHolidayNotFound)
+}
+
+func TestDayOffService_SubmitDayOffOverHoliday(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	holidayRepo := repository.NewHolidayRepo(tx)
+	svc := NewDayOffService(repository.NewDayOffRepo(tx), repository.NewTransactor(tx), employeeRepo, holidayRepo, repository.NewLeaveTypeRepo(tx),
+		NewLeaveBalanceService(repository.NewLeaveBalanceRepo(tx), employeeRepo, repository.NewLeaveTypeRepo(tx)), NewAuditService(repository.NewAuditRepo(tx)), worktime.Standard())
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-5, 0, 0)
+	employee.Region = "TW"
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+	elsewhere := repository.MockEmployee()
+	elsewhere.OnboardDate = employee.OnboardDate
+	require.NoError(t, employeeRepo.Create(ctx, elsewhere))
+
+	monday := nextMonday()
+	wednesday := monday.AddDate(0, 0, 2).Truncate(24 * time.Hour)
+	require.NoError(t, holidayRepo.Create(ctx, &model.Holiday{Region: "TW", Date: wednesday, Name: "Holiday"}))
+
+	week := func(employeeID uint) *model.DayOffRecord {
+		return &model.DayOffRecord{
+			EmployeeID: employeeID,
+			DayOffType: "PTO",
+			Reason:     "vacation",
+			StartTime:  monday,
+			EndTime:    monday.AddDate(0, 0, 4).Add(9 * time.Hour),
+		}
+	}
+	created, err := svc.SubmitDayOff(ctx, week(employee.ID))
+	require.NoError(t, err)
+	require.Equal(t, 32.0, created.DurationHours)
+	require.Equal(t, 4.0, created.DurationDays)
+
+	// the holiday is not working time, so a day off on it overlaps nothing, and is no leave
+	_, err = svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "PTO",
+		Reason:     "holiday",
+		StartTime:  wednesday.Add(9 * time.Hour),
+		EndTime:    wednesday.Add(18 * time.Hour),
+	})
+	require.ErrorIs(t, err, ErrNoWorkingTime)
+
+	// employees of other regions work on it
+	created, err = svc.SubmitDayOff(ctx, week(elsewhere.ID))
+	require.NoError(t, err)
+	require.Equal(t, 5.0, created.DurationDays)
+}
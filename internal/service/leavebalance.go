@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+)
+
+type LeaveBalanceService interface {
+	ListBalances(ctx context.Context, employeeID uint, year int) ([]model.LeaveBalance, error)
+	EnsureSufficient(ctx context.Context, employee *model.Employee, record *model.DayOffRecord) error
+	Debit(ctx context.Context, employee *model.Employee, record *model.DayOffRecord) error
+	Credit(ctx context.Context, record *model.DayOffRecord, note string) error
+}
+
+type leaveBalanceService struct {
+	repo          repository.LeaveBalance
+	employeeRepo  repository.Employee
+	leaveTypeRepo repository.LeaveType
+}
+
+func NewLeaveBalanceService(repo repository.LeaveBalance, employeeRepo repository.Employee, leaveTypeRepo repository.LeaveType) LeaveBalanceService {
+	return &leaveBalanceService{
+		repo:          repo,
+		employeeRepo:  employeeRepo,
+		leaveTypeRepo: leaveTypeRepo,
+	}
+}
+
+// AnnualEntitlements returns the days granted in year for every leave type, by code.
+func AnnualEntitlements(leaveTypes []model.LeaveType, employee *model.Employee, year int) map[string]float64 {
+	entitlements := make(map[string]float64, len(leaveTypes))
+	for _, leaveType := range leaveTypes {
+		entitlements[leaveType.Code] = annualEntitlement(&leaveType, employee, year)
+	}
+	return entitlements
+}
+
+// annualEntitlement returns the number of days of a leave type granted in year. Seniority based
+// leave grows with the years of service, flat leave is pro-rated in the onboarding year.
+func annualEntitlement(leaveType *model.LeaveType, employee *model.Employee, year int) float64 {
+	if leaveType.Entitlement == model.LeaveEntitlementSeniority {
+		return ptoEntitlement(employee, year)
+	}
+	return flatEntitlement(leaveType.AnnualDays, employee, year)
+}
+
+// levelBonusDays are extra PTO days granted on top of the seniority based amount.
+var levelBonusDays = map[string]float64{
+	"Senior":    1,
+	"Staff":     2,
+	"Principal": 3,
+	"Manager":   2,
+	"Director":  3,
+}
+
+func ptoEntitlement(employee *model.Employee, year int) float64 {
+	months := serviceMonths(employee.OnboardDate, year)
+	var days float64
+	switch {
+	case months < 6:
+		return 0
+	case months < 12:
+		days = 3
+	case months < 24:
+		days = 7
+	case months < 36:
+		days = 10
+	case months < 60:
+		days = 14
+	case months < 120:
+		days = 15
+	default:
+		// one more day for every year beyond ten, up to thirty days
+		days = math.Min(15+float64(months/12-9), 30)
+	}
+	return days + levelBonusDays[employee.Level]
+}
+
+func flatEntitlement(days float64, employee *model.Employee, year int) float64 {
+	if employee.OnboardDate.Year() > year {
+		return 0
+	}
+	if employee.OnboardDate.Year() < year {
+		return days
+	}
+	remainingMonths := 12 - int(employee.OnboardDate.Month()) + 1
+	return roundHalfDay(days * float64(remainingMonths) / 12)
+}
+
+// serviceMonths counts the completed months of service at the end of the given year.
+func serviceMonths(onboardDate time.Time, year int) int {
+	return completedMonths(onboardDate, time.Date(year, time.December, 31, 0, 0, 0, 0, onboardDate.Location()))
+}
+
+// completedMonths counts the completed months from since to t.
+func completedMonths(since, t time.Time) int {
+	if since.After(t) {
+		return 0
+	}
+	months := (t.Year()-since.Year())*12 + int(t.Month()) - int(since.Month())
+	if t.Day() < since.Day() {
+		months--
+	}
+	return months
+}
+
+func roundHalfDay(days float64) float64 {
+	return math.Ceil(days*2) / 2
+}
+
+func (s *leaveBalanceService) ListBalances(ctx context.Context, employeeID uint, year int) ([]model.LeaveBalance, error) {
+	ctx, span := tracer.Start(ctx, "LeaveBalanceService.ListBalances")
+	defer span.End()
+
+	employee, err := s.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w by id: %d", ErrEmployeeNotFound, employeeID)
+		}
+		return nil, err
+	}
+	if err = authorizeSelfOrManager(ctx, employee); err != nil {
+		return nil, err
+	}
+	// only the years of the employment up to now are accrued, looking at any other year books nothing
+	if year >= employee.OnboardDate.Year() && year <= time.Now().Year() {
+		if err = s.accrue(ctx, employee, year); err != nil {
+			return nil, err
+		}
+		return s.repo.ListBalances(ctx, employeeID, year)
+	}
+	return s.computeBalances(ctx, employee, year)
+}
+
+// computeBalances returns the balances of a year that is not accrued by looking at it. Leave types
+// without entries in the year, e.g. as no request has been made for it yet, have their entitlement
+// computed instead.
+func (s *leaveBalanceService) computeBalances(ctx context.Context, employee *model.Employee, year int) ([]model.LeaveBalance, error) {
+	balances, err := s.repo.ListBalances(ctx, employee.ID, year)
+	if err != nil {
+		return nil, err
+	}
+	leaveTypes, err := s.leaveTypeRepo.List(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, leaveType := range leaveTypes {
+		booked := slices.ContainsFunc(balances, func(balance model.LeaveBalance) bool {
+			return balance.DayOffType == leaveType.Code
+		})
+		if !leaveType.Paid || booked {
+			continue
+		}
+		days := annualEntitlement(&leaveType, employee, year)
+		balances = append(balances, model.LeaveBalance{DayOffType: leaveType.Code, Year: year, Entitled: days, Remaining: days})
+	}
+	slices.SortFunc(balances, func(a, b model.LeaveBalance) int {
+		return strings.Compare(a.DayOffType, b.DayOffType)
+	})
+	return balances, nil
+}
+
+func (s *leaveBalanceService) EnsureSufficient(ctx context.Context, employee *model.Employee, record *model.DayOffRecord) error {
+	ctx, span := tracer.Start(ctx, "LeaveBalanceService.EnsureSufficient")
+	defer span.End()
+
+	year := record.StartTime.Year()
+	if err := s.accrue(ctx, employee, year); err != nil {
+		return err
+	}
+
+	balances, err := s.repo.ListBalances(ctx, employee.ID, year)
+	if err != nil {
+		return err
+	}
+	var remaining float64
+	for _, balance := range balances {
+		if balance.DayOffType == record.DayOffType {
+			remaining = balance.Remaining
+		}
+	}
+
+	if record.DurationDays > remaining {
+		return fmt.Errorf("%w: %g days of %s requested, %g remaining", ErrInsufficientLeaveBalance, record.DurationDays, record.DayOffType, remaining)
+	}
+	return nil
+}
+
+func (s *leaveBalanceService) Debit(ctx context.Context, employee *model.Employee, record *model.DayOffRecord) error {
+	ctx, span := tracer.Start(ctx, "LeaveBalanceService.Debit")
+	defer span.End()
+
+	return s.repo.CreateEntry(ctx, &model.LeaveLedgerEntry{
+		EmployeeID:     employee.ID,
+		Year:           record.StartTime.Year(),
+		DayOffType:     record.DayOffType,
+		Kind:           model.LeaveEntryDebit,
+		Days:           -record.DurationDays,
+		DayOffRecordID: &record.ID,
+	})
+}
+
+func (s *leaveBalanceService) Credit(ctx context.Context, record *model.DayOffRecord, note string) error {
+	ctx, span := tracer.Start(ctx, "LeaveBalanceService.Credit")
+	defer span.End()
+
+	net, err := s.repo.SumByDayOffRecord(ctx, record.ID)
+	if err != nil {
+		return err
+	}
+	if net >= 0 {
+		// nothing left to give back
+		return nil
+	}
+
+	return s.repo.CreateEntry(ctx, &model.LeaveLedgerEntry{
+		EmployeeID:     record.EmployeeID,
+		Year:           record.StartTime.Year(),
+		DayOffType:     record.DayOffType,
+		Kind:           model.LeaveEntryCredit,
+		Days:           -net,
+		DayOffRecordID: &record.ID,
+		Note:           note,
+	})
+}
+
+// accrue books the yearly entitlement of every active paid leave type once per employee and year,
+// the ones booked already are kept.
+func (s *leaveBalanceService) accrue(ctx context.Context, employee *model.Employee, year int) error {
+	leaveTypes, err := s.leaveTypeRepo.List(ctx, false)
+	if err != nil {
+		return err
+	}
+	for _, leaveType := range leaveTypes {
+		if !leaveType.Paid {
+			continue
+		}
+		err = s.repo.CreateAccrual(ctx, &model.LeaveLedgerEntry{
+			EmployeeID: employee.ID,
+			Year:       year,
+			DayOffType: leaveType.Code,
+			Kind:       model.LeaveEntryAccrual,
+			Days:       annualEntitlement(&leaveType, employee, year),
+			Note:       fmt.Sprintf("%d entitlement", year),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
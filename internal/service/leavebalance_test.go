@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/internal/worktime"
+)
+
+func TestPtoEntitlement(t *testing.T) {
+	tests := []struct {
+		name        string
+		onboardDate time.Time
+		level       string
+		want        float64
+	}{
+		{"joined this year", time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC), "", 0},
+		{"half a year", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), "", 3},
+		{"four years", time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC), "", 14},
+		{"four years senior", time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC), "Senior", 15},
+		{"twelve years", time.Date(2012, time.January, 1, 0, 0, 0, 0, time.UTC), "", 18},
+		{"capped", time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC), "", 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			employee := &model.Employee{OnboardDate: tt.onboardDate, Level: tt.level}
+			require.Equal(t, tt.want, ptoEntitlement(employee, 2024))
+		})
+	}
+}
+
+func TestLeaveBalanceService_ListBalances(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	balanceSvc := NewLeaveBalanceService(repository.NewLeaveBalanceRepo(tx), employeeRepo, repository.NewLeaveTypeRepo(tx))
+	svc := NewDayOffService(repository.NewDayOffRepo(tx), repository.NewTransactor(tx), employeeRepo, repository.NewHolidayRepo(tx), repository.NewLeaveTypeRepo(tx), balanceSvc, NewAuditService(repository.NewAuditRepo(tx)), worktime.Standard())
+
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-2, -6, 0)
+	require.NoError(t, employeeRepo.Create(context.Background(), employee))
+
+	ctx := context.Background()
+	start := nextMonday()
+	created, err := svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "bereavement",
+		Reason:     "funeral",
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	balances, err := balanceSvc.ListBalances(ctx, employee.ID, start.Year())
+	require.NoError(t, err)
+	// one balance for each of the leave types the migrations install
+	require.Len(t, balances, 4)
+	for _, balance := range balances {
+		if balance.DayOffType == "bereavement" {
+			require.Equal(t, 0.125, balance.Used)
+			require.Equal(t, balance.Entitled-0.125, balance.Remaining)
+		}
+	}
+
+	_, err = svc.CancelDayOff(ctx, created.ID, "not needed")
+	require.NoError(t, err)
+	balances, err = balanceSvc.ListBalances(ctx, employee.ID, start.Year())
+	require.NoError(t, err)
+	for _, balance := range balances {
+		require.Equal(t, balance.Entitled, balance.Remaining)
+	}
+
+	_, err = svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "bereavement",
+		Reason:     "funeral",
+		StartTime:  start,
+		EndTime:    start.AddDate(0, 0, 40),
+	})
+	require.ErrorIs(t, err, ErrInsufficientLeaveBalance)
+}
+
+func TestLeaveBalanceService_ListBalancesOutsideEmployment(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	repo := repository.NewLeaveBalanceRepo(tx)
+	svc := NewLeaveBalanceService(repo, employeeRepo, repository.NewLeaveTypeRepo(tx))
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-5, 0, 0)
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+
+	for _, year := range []int{employee.OnboardDate.Year() - 1, 3000} {
+		balances, err := svc.ListBalances(ctx, employee.ID, year)
+		require.NoError(t, err)
+		require.Len(t, balances, 4)
+		for _, balance := range balances {
+			require.Zero(t, balance.Used)
+			require.Equal(t, balance.Entitled, balance.Remaining)
+			if year < employee.OnboardDate.Year() {
+				require.Zero(t, balance.Entitled)
+			}
+		}
+		// nothing is booked for the year
+		booked, err := repo.ListBalances(ctx, employee.ID, year)
+		require.NoError(t, err)
+		require.Empty(t, booked)
+	}
+}
+
+func TestLeaveBalanceService_AccruesOnce(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	repo := repository.NewLeaveBalanceRepo(tx)
+	svc := NewLeaveBalanceService(repo, employeeRepo, repository.NewLeaveTypeRepo(tx))
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-5, 0, 0)
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+
+	// an accrual booked concurrently by another lookup is not booked again
+	year := time.Now().Year()
+	require.NoError(t, repo.CreateAccrual(ctx, &model.LeaveLedgerEntry{
+		EmployeeID: employee.ID, Year: year, DayOffType: "sick leave", Kind: model.LeaveEntryAccrual, Days: 99,
+	}))
+	for range 2 {
+		balances, err := svc.ListBalances(ctx, employee.ID, year)
+		require.NoError(t, err)
+		require.Len(t, balances, 4)
+		for _, balance := range balances {
+			if balance.DayOffType == "sick leave" {
+				require.Equal(t, 99.0, balance.Entitled)
+			}
+		}
+	}
+}
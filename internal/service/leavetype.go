@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+)
+
+type LeaveTypeService interface {
+	// ListLeaveTypes returns the leave types by code, only the active ones unless inactive is set.
+	ListLeaveTypes(ctx context.Context, inactive bool) ([]model.LeaveType, error)
+	GetLeaveType(ctx context.Context, id uint) (*model.LeaveType, error)
+	CreateLeaveType(ctx context.Context, leaveType *model.LeaveType) (*model.LeaveType, error)
+	UpdateLeaveType(ctx context.Context, leaveType *model.LeaveType) (*model.LeaveType, error)
+	// DeleteLeaveType removes a leave type nothing refers to yet, it fails with ErrLeaveTypeInUse for the
+	// others, which can be deactivated instead.
+	DeleteLeaveType(ctx context.Context, id uint) error
+}
+
+var (
+	ErrLeaveTypeNotFound    = newNotFoundError("leave_type_not_found", "leave type not found")
+	ErrLeaveTypeExists      = newConflictError("leave_type_exists", "code", "a leave type with this code already exists")
+	ErrLeaveTypeInUse       = newConflictError("leave_type_in_use", "", "the leave type has day offs, deactivate it instead")
+	ErrLeaveTypeCodeChanged = newConflictError("leave_type_code_in_use", "code", "the code of a leave type with day offs cannot change")
+)
+
+type leaveTypeService struct {
+	repo         repository.LeaveType
+	transactor   repository.Transactor
+	auditService AuditService
+}
+
+func NewLeaveTypeService(repo repository.LeaveType, transactor repository.Transactor, auditService AuditService) LeaveTypeService {
+	return &leaveTypeService{
+		repo:         repo,
+		transactor:   transactor,
+		auditService: auditService,
+	}
+}
+
+func (s *leaveTypeService) ListLeaveTypes(ctx context.Context, inactive bool) ([]model.LeaveType, error) {
+	ctx, span := tracer.Start(ctx, "LeaveTypeService.ListLeaveTypes")
+	defer span.End()
+
+	return s.repo.List(ctx, inactive)
+}
+
+func (s *leaveTypeService) GetLeaveType(ctx context.Context, id uint) (*model.LeaveType, error) {
+	ctx, span := tracer.Start(ctx, "LeaveTypeService.GetLeaveType")
+	defer span.End()
+
+	leaveType, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w by id: %d", ErrLeaveTypeNotFound, id)
+	}
+	return leaveType, err
+}
+
+func (s *leaveTypeService) CreateLeaveType(ctx context.Context, leaveType *model.LeaveType) (*model.LeaveType, error) {
+	ctx, span := tracer.Start(ctx, "LeaveTypeService.CreateLeaveType")
+	defer span.End()
+
+	if err := s.ensureUnique(ctx, leaveType.Code, 0); err != nil {
+		return nil, err
+	}
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, leaveType); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, model.AuditEntityLeaveType, leaveType.ID, model.AuditOperationCreate, nil, leaveType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, leaveType.ID)
+}
+
+func (s *leaveTypeService) UpdateLeaveType(ctx context.Context, leaveType *model.LeaveType) (*model.LeaveType, error) {
+	ctx, span := tracer.Start(ctx, "LeaveTypeService.UpdateLeaveType")
+	defer span.End()
+
+	existed, err := s.GetLeaveType(ctx, leaveType.ID)
+	if err != nil {
+		return nil, err
+	}
+	if leaveType.Code != existed.Code {
+		// records and balances refer to the type by its code
+		used, err := s.repo.IsUsed(ctx, existed.Code)
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			return nil, fmt.Errorf("%w: %s", ErrLeaveTypeCodeChanged, existed.Code)
+		}
+		if err = s.ensureUnique(ctx, leaveType.Code, existed.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	before := *existed
+	leaveType.CreatedAt = existed.CreatedAt
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, leaveType); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, model.AuditEntityLeaveType, leaveType.ID, model.AuditOperationUpdate, &before, leaveType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, leaveType.ID)
+}
+
+func (s *leaveTypeService) DeleteLeaveType(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "LeaveTypeService.DeleteLeaveType")
+	defer span.End()
+
+	existed, err := s.GetLeaveType(ctx, id)
+	if err != nil {
+		return err
+	}
+	used, err := s.repo.IsUsed(ctx, existed.Code)
+	if err != nil {
+		return err
+	}
+	if used {
+		return fmt.Errorf("%w: %s", ErrLeaveTypeInUse, existed.Code)
+	}
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, model.AuditEntityLeaveType, id, model.AuditOperationDelete, existed, nil)
+	})
+}
+
+// ensureUnique checks that no leave type other than the one with the given id has the code.
+func (s *leaveTypeService) ensureUnique(ctx context.Context, code string, id uint) error {
+	existed, err := s.repo.GetByCode(ctx, code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existed.ID != id {
+		return fmt.Errorf("%w: %s", ErrLeaveTypeExists, code)
+	}
+	return nil
+}
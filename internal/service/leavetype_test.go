@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joremysh/fliqt/internal/model"
+	"github.com/joremysh/fliqt/internal/repository"
+	"github.com/joremysh/fliqt/internal/worktime"
+)
+
+func TestLeaveTypeService_CRUD(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	svc := NewLeaveTypeService(repository.NewLeaveTypeRepo(tx), repository.NewTransactor(tx), NewAuditService(repository.NewAuditRepo(tx)))
+	ctx := context.Background()
+
+	leaveTypes, err := svc.ListLeaveTypes(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, leaveTypes, 4)
+
+	created, err := svc.CreateLeaveType(ctx, &model.LeaveType{
+		Code: "volunteering", Name: "Volunteering", Paid: true, RequiresApproval: true,
+		Entitlement: model.LeaveEntitlementFlat, AnnualDays: 2, Active: true,
+	})
+	require.NoError(t, err)
+	_, err = svc.CreateLeaveType(ctx, &model.LeaveType{Code: "PTO", Name: "Again", Entitlement: model.LeaveEntitlementFlat})
+	require.ErrorIs(t, err, ErrLeaveTypeExists)
+
+	// an unused type may be renamed, code and all
+	created.Code = "volunteer day"
+	created.Active = false
+	updated, err := svc.UpdateLeaveType(ctx, created)
+	require.NoError(t, err)
+	require.Equal(t, "volunteer day", updated.Code)
+	require.False(t, updated.Active)
+
+	leaveTypes, err = svc.ListLeaveTypes(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, leaveTypes, 4)
+	leaveTypes, err = svc.ListLeaveTypes(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, leaveTypes, 5)
+
+	require.NoError(t, svc.DeleteLeaveType(ctx, created.ID))
+	_, err = svc.GetLeaveType(ctx, created.ID)
+	require.ErrorIs(t, err, ErrLeaveTypeNotFound)
+}
+
+func TestDayOffService_SubmitDayOffLeaveTypeRules(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	leaveTypeRepo := repository.NewLeaveTypeRepo(tx)
+	auditSvc := NewAuditService(repository.NewAuditRepo(tx))
+	leaveTypeSvc := NewLeaveTypeService(leaveTypeRepo, repository.NewTransactor(tx), auditSvc)
+	svc := NewDayOffService(repository.NewDayOffRepo(tx), repository.NewTransactor(tx), employeeRepo, repository.NewHolidayRepo(tx), leaveTypeRepo,
+		NewLeaveBalanceService(repository.NewLeaveBalanceRepo(tx), employeeRepo, leaveTypeRepo), auditSvc, worktime.Standard())
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(0, -2, 0)
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+
+	study, err := leaveTypeSvc.CreateLeaveType(ctx, &model.LeaveType{
+		Code: "study", Name: "Study leave", RequiresAttachment: true, MaxConsecutiveDays: 2,
+		Entitlement: model.LeaveEntitlementFlat, AnnualDays: 10, Active: true,
+	})
+	require.NoError(t, err)
+
+	monday := nextMonday()
+	request := func(dayOffType string, days int, attachment string) *model.DayOffRecord {
+		return &model.DayOffRecord{
+			EmployeeID:    employee.ID,
+			DayOffType:    dayOffType,
+			Reason:        "exam",
+			AttachmentURL: attachment,
+			StartTime:     monday,
+			EndTime:       monday.AddDate(0, 0, days-1).Add(9 * time.Hour),
+		}
+	}
+
+	_, err = svc.SubmitDayOff(ctx, request("sabbatical", 1, ""))
+	require.ErrorIs(t, err, ErrInvalidDayOffType)
+	_, err = svc.SubmitDayOff(ctx, request("study", 1, ""))
+	require.ErrorIs(t, err, ErrAttachmentRequired)
+	_, err = svc.SubmitDayOff(ctx, request("study", 3, "https://example.com/exam.pdf"))
+	require.ErrorIs(t, err, ErrTooManyConsecutiveDays)
+
+	// leave that needs no approval is approved on submission
+	study.RequiresApproval = false
+	_, err = leaveTypeSvc.UpdateLeaveType(ctx, study)
+	require.NoError(t, err)
+	created, err := svc.SubmitDayOff(ctx, request("study", 2, "https://example.com/exam.pdf"))
+	require.NoError(t, err)
+	require.Equal(t, model.DayOffStatusApproved, created.Status)
+	require.NotNil(t, created.ReviewedAt)
+
+	// the code of a type with day offs is kept
+	study.Code = "studies"
+	_, err = leaveTypeSvc.UpdateLeaveType(ctx, study)
+	require.ErrorIs(t, err, ErrLeaveTypeCodeChanged)
+	require.ErrorIs(t, leaveTypeSvc.DeleteLeaveType(ctx, study.ID), ErrLeaveTypeInUse)
+
+	study.Code = "study"
+	study.MinServiceMonths = 3
+	_, err = leaveTypeSvc.UpdateLeaveType(ctx, study)
+	require.NoError(t, err)
+	_, err = svc.SubmitDayOff(ctx, request("study", 1, "https://example.com/exam.pdf"))
+	require.ErrorIs(t, err, ErrNotEligible)
+
+	study.MinServiceMonths = 0
+	study.MinNoticeDays = 30
+	_, err = leaveTypeSvc.UpdateLeaveType(ctx, study)
+	require.NoError(t, err)
+	_, err = svc.SubmitDayOff(ctx, request("study", 1, "https://example.com/exam.pdf"))
+	require.ErrorIs(t, err, ErrInsufficientNotice)
+
+	study.Active = false
+	_, err = leaveTypeSvc.UpdateLeaveType(ctx, study)
+	require.NoError(t, err)
+	_, err = svc.SubmitDayOff(ctx, request("study", 1, "https://example.com/exam.pdf"))
+	require.ErrorIs(t, err, ErrInvalidDayOffType)
+}
+
+func TestDayOffService_SubmitDayOffUnpaid(t *testing.T) {
+	tx := gdb.Begin()
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+	employeeRepo := repository.NewEmployeeRepo(tx)
+	leaveTypeRepo := repository.NewLeaveTypeRepo(tx)
+	balanceRepo := repository.NewLeaveBalanceRepo(tx)
+	auditSvc := NewAuditService(repository.NewAuditRepo(tx))
+	balanceSvc := NewLeaveBalanceService(balanceRepo, employeeRepo, leaveTypeRepo)
+	svc := NewDayOffService(repository.NewDayOffRepo(tx), repository.NewTransactor(tx), employeeRepo, repository.NewHolidayRepo(tx), leaveTypeRepo,
+		balanceSvc, auditSvc, worktime.Standard())
+
+	ctx := context.Background()
+	employee := repository.MockEmployee()
+	employee.OnboardDate = time.Now().AddDate(-1, 0, 0)
+	require.NoError(t, employeeRepo.Create(ctx, employee))
+
+	_, err := NewLeaveTypeService(leaveTypeRepo, repository.NewTransactor(tx), auditSvc).CreateLeaveType(ctx, &model.LeaveType{
+		Code: "unpaid leave", Name: "Unpaid leave", RequiresApproval: true, Entitlement: model.LeaveEntitlementFlat, Active: true,
+	})
+	require.NoError(t, err)
+
+	// unpaid leave has no balance to run out of
+	monday := nextMonday()
+	created, err := svc.SubmitDayOff(ctx, &model.DayOffRecord{
+		EmployeeID: employee.ID,
+		DayOffType: "unpaid leave",
+		Reason:     "travel",
+		StartTime:  monday,
+		EndTime:    monday.AddDate(0, 0, 4).Add(9 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5.0, created.DurationDays)
+	booked, err := balanceRepo.SumByDayOffRecord(ctx, created.ID)
+	require.NoError(t, err)
+	require.Zero(t, booked)
+
+	balances, err := balanceSvc.ListBalances(ctx, employee.ID, monday.Year())
+	require.NoError(t, err)
+	for _, balance := range balances {
+		require.NotEqual(t, "unpaid leave", balance.DayOffType)
+	}
+
+	_, err = svc.CancelDayOff(ctx, created.ID, "stayed home")
+	require.NoError(t, err)
+	booked, err = balanceRepo.SumByDayOffRecord(ctx, created.ID)
+	require.NoError(t, err)
+	require.Zero(t, booked)
+}
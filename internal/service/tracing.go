@@ -0,0 +1,6 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer records a span for every service method, nested in the span of the handler.
+var tracer = otel.Tracer("github.com/joremysh/fliqt/internal/service")
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/joremysh/fliqt/internal/routes"
+)
+
+var tracer = otel.Tracer("github.com/joremysh/fliqt/internal/tracing")
+
+// NewOperationMiddleware starts a span named after the OpenAPI operation around the handler.
+// Registered after the request validator, the span covers the HRSystem operation only.
+func NewOperationMiddleware(swagger *openapi3.T) gin.HandlerFunc {
+	operations := routes.NewOperations(swagger)
+	return func(c *gin.Context) {
+		operation, ok := operations.Lookup(c)
+		if !ok {
+			c.Next()
+			return
+		}
+		ctx, span := tracer.Start(c.Request.Context(), "HRSystem."+operation,
+			trace.WithAttributes(attribute.String("openapi.operation_id", operation)))
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		if status := c.Writer.Status(); status >= 500 {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
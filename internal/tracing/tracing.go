@@ -0,0 +1,95 @@
+// Package tracing sets up OpenTelemetry tracing with W3C trace context propagation.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Exporters accepted by Setup.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+type Options struct {
+	ServiceName    string
+	ServiceVersion string
+	Exporter       string
+	// OTLPEndpoint is the URL of the OTLP/HTTP collector, e.g. http://localhost:4318. When empty
+	// the standard OTEL_EXPORTER_OTLP_* variables apply.
+	OTLPEndpoint string
+	// File receives the spans of the stdout exporter instead of standard output.
+	File string
+	// SampleRatio is the fraction of new traces that are recorded. Traces started upstream
+	// follow the sampling decision of the caller.
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider and propagator. The returned function flushes
+// pending spans and has to be called before the process exits.
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+	switch options.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		var otlpOptions []otlptracehttp.Option
+		if options.OTLPEndpoint != "" {
+			otlpOptions = append(otlpOptions, otlptracehttp.WithEndpointURL(options.OTLPEndpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, otlpOptions...)
+	case ExporterStdout:
+		var writer io.Writer = os.Stdout
+		if options.File != "" {
+			file, openErr := os.OpenFile(options.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if openErr != nil {
+				return nil, openErr
+			}
+			writer, closer = file, file
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(writer))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %q", options.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(options.ServiceName),
+			semconv.ServiceVersion(options.ServiceVersion),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
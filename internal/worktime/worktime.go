@@ -0,0 +1,195 @@
+// Package worktime measures leave in working time, according to a weekly work schedule.
+package worktime
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// HalfDay is the morning, before the lunch break, or the afternoon, after it.
+type HalfDay string
+
+const (
+	AM HalfDay = "AM"
+	PM HalfDay = "PM"
+)
+
+// Schedule is the same working hours on every working day. The clock times are offsets from
+// midnight in Location; the lunch break is not working time, and neither are holidays.
+type Schedule struct {
+	WorkingDays []time.Weekday
+	DayStart    time.Duration
+	DayEnd      time.Duration
+	LunchStart  time.Duration
+	LunchEnd    time.Duration
+	Location    *time.Location
+
+	holidays map[civilDate]bool
+}
+
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// Standard is Monday to Friday from 9:00 to 18:00 UTC, with lunch from 12:00 to 13:00.
+func Standard() Schedule {
+	return Schedule{
+		WorkingDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		DayStart:    9 * time.Hour,
+		DayEnd:      18 * time.Hour,
+		LunchStart:  12 * time.Hour,
+		LunchEnd:    13 * time.Hour,
+		Location:    time.UTC,
+	}
+}
+
+func (s Schedule) Validate() error {
+	var errs []error
+	if len(s.WorkingDays) == 0 {
+		errs = append(errs, errors.New("at least one working day is required"))
+	}
+	if s.DayStart < 0 || s.DayEnd > 24*time.Hour || s.DayStart >= s.DayEnd {
+		errs = append(errs, errors.New("the working day must start before it ends, within a day"))
+	}
+	if s.LunchStart < s.DayStart || s.LunchEnd > s.DayEnd || s.LunchStart > s.LunchEnd {
+		errs = append(errs, errors.New("the lunch break must lie within the working day"))
+	}
+	if s.Location == nil {
+		errs = append(errs, errors.New("a location is required"))
+	}
+	return errors.Join(errs...)
+}
+
+// DailyHours is the working time of a working day.
+func (s Schedule) DailyHours() time.Duration {
+	return s.DayEnd - s.DayStart - (s.LunchEnd - s.LunchStart)
+}
+
+// WithHolidays returns a copy of the schedule on which the given dates are not worked. The year,
+// month and day of every date are taken as they are, whatever its location.
+func (s Schedule) WithHolidays(dates ...time.Time) Schedule {
+	holidays := make(map[civilDate]bool, len(s.holidays)+len(dates))
+	for d := range s.holidays {
+		holidays[d] = true
+	}
+	for _, t := range dates {
+		year, month, day := t.Date()
+		holidays[civilDate{year, month, day}] = true
+	}
+	s.holidays = holidays
+	return s
+}
+
+func (s Schedule) IsWorkingDay(t time.Time) bool {
+	t = t.In(s.Location)
+	year, month, day := t.Date()
+	if s.holidays[civilDate{year, month, day}] {
+		return false
+	}
+	weekday := t.Weekday()
+	for _, day := range s.WorkingDays {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkingTime returns the working time between start and end, to the minute.
+func (s Schedule) WorkingTime(start, end time.Time) time.Duration {
+	if !end.After(start) {
+		return 0
+	}
+	var total time.Duration
+	for day := s.midnight(start); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !s.IsWorkingDay(day) {
+			continue
+		}
+		total += overlap(start, end, day.Add(s.DayStart), day.Add(s.LunchStart))
+		total += overlap(start, end, day.Add(s.LunchEnd), day.Add(s.DayEnd))
+	}
+	return total.Truncate(time.Minute)
+}
+
+// HalfDay returns the working hours of the half of date's day.
+func (s Schedule) HalfDay(date time.Time, half HalfDay) (start, end time.Time) {
+	day := s.midnight(date)
+	if half == AM {
+		return day.Add(s.DayStart), day.Add(s.LunchStart)
+	}
+	return day.Add(s.LunchEnd), day.Add(s.DayEnd)
+}
+
+// Leave measures leave taken from start to end in working hours and days. A half day is half
+// of a working day, however the lunch break splits it.
+func (s Schedule) Leave(start, end time.Time, half HalfDay) (hours, days float64) {
+	working := s.WorkingTime(start, end)
+	if half != "" && working > 0 {
+		working = s.DailyHours() / 2
+	}
+	return round(working.Hours(), 2), round(working.Hours()/s.DailyHours().Hours(), 4)
+}
+
+func (s Schedule) midnight(t time.Time) time.Time {
+	t = t.In(s.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.Location)
+}
+
+func overlap(start, end, from, to time.Time) time.Duration {
+	if from.Before(start) {
+		from = start
+	}
+	if to.After(end) {
+		to = end
+	}
+	if !to.After(from) {
+		return 0
+	}
+	return to.Sub(from)
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
+
+// ParseClock parses a time of day such as "09:00" into its offset from midnight. "24:00"
+// is accepted as the end of a day.
+func ParseClock(value string) (time.Duration, error) {
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseWeekdays parses a comma separated list of weekdays such as "Mon,Tue,Wed", in short
+// or in full and in any case.
+func ParseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		day, ok := weekdays[name[:min(len(name), 3)]]
+		if !ok || (len(name) > 3 && name != strings.ToLower(day.String())) {
+			return nil, fmt.Errorf("invalid weekday %q", name)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
@@ -0,0 +1,148 @@
+package worktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// monday is a Monday at midnight UTC.
+var monday = time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+func TestSchedule_WorkingTime(t *testing.T) {
+	s := Standard()
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       time.Duration
+	}{
+		{"full day", monday.Add(9 * time.Hour), monday.Add(18 * time.Hour), 8 * time.Hour},
+		{"whole calendar day", monday, monday.AddDate(0, 0, 1), 8 * time.Hour},
+		{"morning", monday.Add(9 * time.Hour), monday.Add(12 * time.Hour), 3 * time.Hour},
+		{"across lunch", monday.Add(11 * time.Hour), monday.Add(14 * time.Hour), 2 * time.Hour},
+		{"within lunch", monday.Add(12*time.Hour + 15*time.Minute), monday.Add(12*time.Hour + 45*time.Minute), 0},
+		{"two hours", monday.Add(14 * time.Hour), monday.Add(16 * time.Hour), 2 * time.Hour},
+		{"week", monday, monday.AddDate(0, 0, 7), 40 * time.Hour},
+		{"weekend", monday.AddDate(0, 0, 5), monday.AddDate(0, 0, 7), 0},
+		{"friday afternoon to monday morning", monday.AddDate(0, 0, 4).Add(13 * time.Hour), monday.AddDate(0, 0, 7).Add(12 * time.Hour), 8 * time.Hour},
+		{"seconds are dropped", monday.Add(9 * time.Hour), monday.Add(9*time.Hour + 90*time.Second), time.Minute},
+		{"empty", monday.Add(10 * time.Hour), monday.Add(10 * time.Hour), 0},
+		{"reversed", monday.Add(18 * time.Hour), monday.Add(9 * time.Hour), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, s.WorkingTime(tt.start, tt.end))
+		})
+	}
+}
+
+func TestSchedule_WorkingTimeInLocation(t *testing.T) {
+	taipei, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err)
+	s := Standard()
+	s.Location = taipei
+
+	// 9:00 to 18:00 in Taipei is 1:00 to 10:00 UTC
+	require.Equal(t, 8*time.Hour, s.WorkingTime(monday.Add(time.Hour), monday.Add(10*time.Hour)))
+	require.Equal(t, 3*time.Hour, s.WorkingTime(monday, monday.Add(4*time.Hour)))
+}
+
+func TestSchedule_WithHolidays(t *testing.T) {
+	s := Standard()
+	holidays := s.WithHolidays(monday.AddDate(0, 0, 2), monday.AddDate(0, 0, 5))
+
+	require.False(t, holidays.IsWorkingDay(monday.AddDate(0, 0, 2).Add(10*time.Hour)))
+	require.Equal(t, 32*time.Hour, holidays.WorkingTime(monday, monday.AddDate(0, 0, 7)))
+	// the schedule it was made from is left alone
+	require.Equal(t, 40*time.Hour, s.WorkingTime(monday, monday.AddDate(0, 0, 7)))
+
+	// a half day on a holiday is no leave
+	start, end := holidays.HalfDay(monday.AddDate(0, 0, 2), AM)
+	hours, days := holidays.Leave(start, end, AM)
+	require.Zero(t, hours)
+	require.Zero(t, days)
+
+	more := holidays.WithHolidays(monday)
+	require.Equal(t, 24*time.Hour, more.WorkingTime(monday, monday.AddDate(0, 0, 7)))
+	require.Equal(t, 32*time.Hour, holidays.WorkingTime(monday, monday.AddDate(0, 0, 7)))
+}
+
+func TestSchedule_Leave(t *testing.T) {
+	s := Standard()
+
+	hours, days := s.Leave(monday, monday.AddDate(0, 0, 3), "")
+	require.Equal(t, 24.0, hours)
+	require.Equal(t, 3.0, days)
+
+	hours, days = s.Leave(monday.Add(14*time.Hour), monday.Add(15*time.Hour), "")
+	require.Equal(t, 1.0, hours)
+	require.Equal(t, 0.125, days)
+
+	// the morning is three hours and the afternoon five, both are half a day
+	start, end := s.HalfDay(monday.Add(15*time.Hour), AM)
+	require.Equal(t, monday.Add(9*time.Hour), start)
+	require.Equal(t, monday.Add(12*time.Hour), end)
+	hours, days = s.Leave(start, end, AM)
+	require.Equal(t, 4.0, hours)
+	require.Equal(t, 0.5, days)
+
+	start, end = s.HalfDay(monday, PM)
+	require.Equal(t, monday.Add(13*time.Hour), start)
+	require.Equal(t, monday.Add(18*time.Hour), end)
+	hours, days = s.Leave(start, end, PM)
+	require.Equal(t, 4.0, hours)
+	require.Equal(t, 0.5, days)
+
+	// a half day on a day off is no leave
+	start, end = s.HalfDay(monday.AddDate(0, 0, 5), AM)
+	hours, days = s.Leave(start, end, AM)
+	require.Zero(t, hours)
+	require.Zero(t, days)
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	require.NoError(t, Standard().Validate())
+
+	s := Standard()
+	s.WorkingDays = nil
+	require.Error(t, s.Validate())
+
+	s = Standard()
+	s.DayEnd = s.DayStart
+	require.Error(t, s.Validate())
+
+	s = Standard()
+	s.LunchEnd = s.DayEnd + time.Hour
+	require.Error(t, s.Validate())
+
+	// no lunch break at all is fine
+	s = Standard()
+	s.LunchStart, s.LunchEnd = s.DayStart, s.DayStart
+	require.NoError(t, s.Validate())
+	require.Equal(t, 9*time.Hour, s.DailyHours())
+}
+
+func TestParseClock(t *testing.T) {
+	d, err := ParseClock("09:30")
+	require.NoError(t, err)
+	require.Equal(t, 9*time.Hour+30*time.Minute, d)
+
+	d, err = ParseClock("24:00")
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, d)
+
+	_, err = ParseClock("9am")
+	require.Error(t, err)
+}
+
+func TestParseWeekdays(t *testing.T) {
+	days, err := ParseWeekdays("Sun, mon,Tuesday")
+	require.NoError(t, err)
+	require.Equal(t, []time.Weekday{time.Sunday, time.Monday, time.Tuesday}, days)
+
+	_, err = ParseWeekdays("Mon,Funday")
+	require.Error(t, err)
+	_, err = ParseWeekdays("Monkey")
+	require.Error(t, err)
+}
@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrNotFound is returned by loaders when the value does not exist. GetOrLoad caches
+// the miss for the negative TTL and keeps returning ErrNotFound until it expires.
+var ErrNotFound = errors.New("cache: not found")
+
+// AsideOptions configures an Aside. Name labels its metrics. A zero NegativeTTL disables
+// negative caching, Jitter is the fraction by which TTLs are randomly shortened or extended.
+type AsideOptions struct {
+	Name        string
+	TTL         time.Duration
+	NegativeTTL time.Duration
+	Jitter      float64
+}
+
+// Aside is a cache-aside store on top of a Cache. Concurrent misses for the same key
+// share a single load, and cache failures fall back to the loader.
+type Aside struct {
+	cache   Cache
+	options AsideOptions
+	group   singleflight.Group
+
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a load in progress. It is marked invalidated when its key is invalidated during
+// the load, as the value may then have been read before the write.
+type flight struct {
+	invalidated bool
+}
+
+func NewAside(cache Cache, options AsideOptions) *Aside {
+	return &Aside{cache: cache, options: options, flights: make(map[string]*flight)}
+}
+
+// entry is the stored representation; a missing value is marked explicitly so that it
+// can be told apart from a zero value.
+type entry[T any] struct {
+	Value   *T   `json:"value,omitempty"`
+	Missing bool `json:"missing,omitempty"`
+}
+
+// GetOrLoad returns the value cached under key, calling load and caching its result on a miss.
+func GetOrLoad[T any](ctx context.Context, a *Aside, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	var cached entry[T]
+	err := a.cache.Get(ctx, key, &cached)
+	if err == nil {
+		if cached.Missing {
+			cacheHits.WithLabelValues(a.options.Name).Inc()
+			return nil, ErrNotFound
+		}
+		if cached.Value != nil {
+			cacheHits.WithLabelValues(a.options.Name).Inc()
+			return cached.Value, nil
+		}
+	} else if !errors.Is(err, ErrMiss) {
+		cacheErrors.WithLabelValues(a.options.Name, "get").Inc()
+	}
+	cacheMisses.WithLabelValues(a.options.Name).Inc()
+
+	value, err, _ := a.group.Do(key, func() (any, error) {
+		// the load is shared by every waiting caller, so it must not be cut short by the first one leaving
+		loadCtx := context.WithoutCancel(ctx)
+		f := a.begin(key)
+		defer a.end(key, f)
+		loaded, err := load(loadCtx)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			if a.options.NegativeTTL > 0 {
+				a.store(loadCtx, key, f, entry[T]{Missing: true}, a.options.NegativeTTL)
+			}
+			return nil, err
+		case err != nil:
+			return nil, err
+		}
+		a.store(loadCtx, key, f, entry[T]{Value: loaded}, a.options.TTL)
+		return loaded, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*T), nil
+}
+
+// Invalidate removes the keys, including cached misses. Loads of the keys in progress do not
+// store their values, they may have been read before the write.
+func (a *Aside) Invalidate(ctx context.Context, keys ...string) error {
+	a.mu.Lock()
+	for _, key := range keys {
+		if f, ok := a.flights[key]; ok {
+			f.invalidated = true
+		}
+		// callers arriving after the write must not join a load that may have read the old value
+		a.group.Forget(key)
+	}
+	a.mu.Unlock()
+	if err := a.cache.Delete(ctx, keys...); err != nil {
+		cacheErrors.WithLabelValues(a.options.Name, "delete").Inc()
+		return err
+	}
+	return nil
+}
+
+// begin registers a load of key, it must be registered before the loader reads.
+func (a *Aside) begin(key string) *flight {
+	f := &flight{}
+	a.mu.Lock()
+	a.flights[key] = f
+	a.mu.Unlock()
+	return f
+}
+
+// end unregisters the load, unless a later load of the key has replaced it.
+func (a *Aside) end(key string, f *flight) {
+	a.mu.Lock()
+	if a.flights[key] == f {
+		delete(a.flights, key)
+	}
+	a.mu.Unlock()
+}
+
+func (a *Aside) invalidated(f *flight) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return f.invalidated
+}
+
+// store sets the value loaded by f unless its key was invalidated during the load. An invalidation
+// racing with the set has either deleted the key after it or is seen by the second check.
+func (a *Aside) store(ctx context.Context, key string, f *flight, value any, ttl time.Duration) {
+	if a.invalidated(f) {
+		return
+	}
+	a.set(ctx, key, value, ttl)
+	if a.invalidated(f) {
+		if err := a.cache.Delete(ctx, key); err != nil {
+			cacheErrors.WithLabelValues(a.options.Name, "delete").Inc()
+		}
+	}
+}
+
+// set stores a loaded value. Failing to store it only costs a later reload, so it is counted but not returned.
+func (a *Aside) set(ctx context.Context, key string, value any, ttl time.Duration) {
+	if err := a.cache.Set(ctx, key, value, a.jitter(ttl)); err != nil {
+		cacheErrors.WithLabelValues(a.options.Name, "set").Inc()
+	}
+}
+
+// jitter spreads expirations so that entries written together do not expire together.
+func (a *Aside) jitter(ttl time.Duration) time.Duration {
+	if a.options.Jitter <= 0 || ttl <= 0 {
+		return ttl
+	}
+	delta := (rand.Float64()*2 - 1) * a.options.Jitter * float64(ttl)
+	return ttl + time.Duration(delta)
+}
@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+func newTestAside(t *testing.T, options AsideOptions) (*Aside, redismock.ClientMock) {
+	client, mock := redismock.NewClientMock()
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+	return NewAside(&RedisClient{Client: client}, options), mock
+}
+
+func TestGetOrLoad_MissLoadsAndStores(t *testing.T) {
+	aside, mock := newTestAside(t, AsideOptions{TTL: time.Hour})
+	mock.ExpectGet("item:1").RedisNil()
+	mock.ExpectSet("item:1", []byte(`{"value":{"name":"one"}}`), time.Hour).SetVal("OK")
+
+	got, err := GetOrLoad(context.Background(), aside, "item:1", func(ctx context.Context) (*item, error) {
+		return &item{Name: "one"}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "one", got.Name)
+}
+
+func TestGetOrLoad_HitSkipsLoader(t *testing.T) {
+	aside, mock := newTestAside(t, AsideOptions{TTL: time.Hour})
+	mock.ExpectGet("item:1").SetVal(`{"value":{"name":"cached"}}`)
+
+	got, err := GetOrLoad(context.Background(), aside, "item:1", func(ctx context.Context) (*item, error) {
+		t.Fatal("loader must not be called on a hit")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "cached", got.Name)
+}
+
+func TestGetOrLoad_NegativeCaching(t *testing.T) {
+	aside, mock := newTestAside(t, AsideOptions{TTL: time.Hour, NegativeTTL: time.Minute})
+	mock.ExpectGet("item:2").RedisNil()
+	mock.ExpectSet("item:2", []byte(`{"missing":true}`), time.Minute).SetVal("OK")
+	mock.ExpectGet("item:2").SetVal(`{"missing":true}`)
+
+	var loads int
+	load := func(ctx context.Context) (*item, error) {
+		loads++
+		return nil, ErrNotFound
+	}
+	_, err := GetOrLoad(context.Background(), aside, "item:2", load)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = GetOrLoad(context.Background(), aside, "item:2", load)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, 1, loads)
+}
+
+func TestGetOrLoad_LoaderErrorIsNotCached(t *testing.T) {
+	aside, mock := newTestAside(t, AsideOptions{TTL: time.Hour, NegativeTTL: time.Minute})
+	mock.ExpectGet("item:3").RedisNil()
+
+	loadErr := errors.New("connection refused")
+	_, err := GetOrLoad(context.Background(), aside, "item:3", func(ctx context.Context) (*item, error) {
+		return nil, loadErr
+	})
+	require.ErrorIs(t, err, loadErr)
+}
+
+func TestGetOrLoad_RedisDownFallsBackToLoader(t *testing.T) {
+	aside, mock := newTestAside(t, AsideOptions{TTL: time.Hour})
+	mock.ExpectGet("item:4").SetErr(errors.New("redis down"))
+	mock.ExpectSet("item:4", []byte(`{"value":{"name":"four"}}`), time.Hour).SetErr(errors.New("redis down"))
+
+	got, err := GetOrLoad(context.Background(), aside, "item:4", func(ctx context.Context) (*item, error) {
+		return &item{Name: "four"}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "four", got.Name)
+}
+
+func TestGetOrLoad_CollapsesConcurrentMisses(t *testing.T) {
+	// without expectations every Redis call fails, so each caller goes for the loader
+	client, _ := redismock.NewClientMock()
+	aside := NewAside(&RedisClient{Client: client}, AsideOptions{TTL: time.Hour})
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*item, error) {
+		loads.Add(1)
+		<-release
+		return &item{Name: "shared"}, nil
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := GetOrLoad(context.Background(), aside, "item:5", load)
+			if assert.NoError(t, err) {
+				assert.Equal(t, "shared", got.Name)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), loads.Load())
+}
+
+func TestAside_Invalidate(t *testing.T) {
+	aside, mock := newTestAside(t, AsideOptions{TTL: time.Hour})
+	mock.ExpectDel("item:1", "item:2").SetVal(2)
+
+	require.NoError(t, aside.Invalidate(context.Background(), "item:1", "item:2"))
+	require.NoError(t, aside.Invalidate(context.Background()))
+}
+
+func TestAside_InvalidateDuringLoad(t *testing.T) {
+	memory := NewMemory(10, 0)
+	aside := NewAside(memory, AsideOptions{TTL: time.Hour})
+
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		got, err := GetOrLoad(context.Background(), aside, "item:7", func(ctx context.Context) (*item, error) {
+			close(loading)
+			<-release
+			return &item{Name: "stale"}, nil
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "stale", got.Name)
+		}
+	}()
+	<-loading
+	require.NoError(t, aside.Invalidate(context.Background(), "item:7"))
+	close(release)
+	<-done
+
+	var cached entry[item]
+	require.ErrorIs(t, memory.Get(context.Background(), "item:7", &cached), ErrMiss, "a value read before the write must not be cached")
+
+	got, err := GetOrLoad(context.Background(), aside, "item:7", func(ctx context.Context) (*item, error) {
+		return &item{Name: "fresh"}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "fresh", got.Name)
+	require.NoError(t, memory.Get(context.Background(), "item:7", &cached))
+	require.Equal(t, "fresh", cached.Value.Name)
+}
+
+func TestAside_Jitter(t *testing.T) {
+	aside := NewAside(nil, AsideOptions{Jitter: 0.1})
+	for range 100 {
+		ttl := aside.jitter(time.Hour)
+		require.GreaterOrEqual(t, ttl, 54*time.Minute)
+		require.LessOrEqual(t, ttl, 66*time.Minute)
+	}
+	require.Equal(t, time.Hour, NewAside(nil, AsideOptions{}).jitter(time.Hour))
+}
+
+func TestGetOrLoad_Metrics(t *testing.T) {
+	aside, mock := newTestAside(t, AsideOptions{Name: "metrics_test", TTL: time.Hour})
+	mock.ExpectGet("item:6").SetErr(errors.New("redis down"))
+	mock.ExpectSet("item:6", []byte(`{"value":{"name":"six"}}`), time.Hour).SetVal("OK")
+	mock.ExpectGet("item:6").SetVal(`{"value":{"name":"six"}}`)
+
+	load := func(ctx context.Context) (*item, error) {
+		return &item{Name: "six"}, nil
+	}
+	for range 2 {
+		_, err := GetOrLoad(context.Background(), aside, "item:6", load)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1.0, testutil.ToFloat64(cacheHits.WithLabelValues("metrics_test")))
+	require.Equal(t, 1.0, testutil.ToFloat64(cacheMisses.WithLabelValues("metrics_test")))
+	require.Equal(t, 1.0, testutil.ToFloat64(cacheErrors.WithLabelValues("metrics_test", "get")))
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key is not cached.
+var ErrMiss = errors.New("cache: miss")
+
+// Cache stores JSON encoded values with an expiration.
+type Cache interface {
+	Get(ctx context.Context, key string, dest interface{}) error
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Backends accepted by New.
+const (
+	BackendRedis  = "redis"
+	BackendMemory = "memory"
+	BackendNone   = "none"
+)
+
+// Options selects and configures the cache backend.
+type Options struct {
+	Backend string
+	Redis   RedisOptions
+	// MemorySize and MemoryMaxTTL bound the in-memory cache.
+	MemorySize   int
+	MemoryMaxTTL time.Duration
+}
+
+// New creates the configured cache. A Redis cache is returned even if Redis cannot be
+// reached yet; reads then fall through to the loaders until it comes back.
+func New(options Options) (Cache, error) {
+	switch options.Backend {
+	case BackendRedis, "":
+		return NewRedisClient(options.Redis)
+	case BackendMemory:
+		return NewMemory(options.MemorySize, options.MemoryMaxTTL), nil
+	case BackendNone:
+		return Noop{}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend: %q", options.Backend)
+	}
+}
+
+// Pinger is implemented by caches backed by a remote server.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
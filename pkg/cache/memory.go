@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process LRU cache. It holds at most size entries and none of them
+// longer than maxTTL, if set. Values are stored encoded, so callers never share them.
+type Memory struct {
+	mu      sync.Mutex
+	size    int
+	maxTTL  time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewMemory(size int, maxTTL time.Duration) *Memory {
+	return &Memory{
+		size:    size,
+		maxTTL:  maxTTL,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	element, ok := m.entries[key]
+	if !ok {
+		m.mu.Unlock()
+		return ErrMiss
+	}
+	entry := element.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && !m.now().Before(entry.expiresAt) {
+		m.remove(element)
+		m.mu.Unlock()
+		return ErrMiss
+	}
+	m.order.MoveToFront(element)
+	value := entry.value
+	m.mu.Unlock()
+
+	return json.Unmarshal(value, dest)
+}
+
+func (m *Memory) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if m.maxTTL > 0 && (expiration <= 0 || expiration > m.maxTTL) {
+		expiration = m.maxTTL
+	}
+	if m.size <= 0 {
+		return nil
+	}
+	entry := &memoryEntry{key: key, value: jsonBytes}
+	if expiration > 0 {
+		entry.expiresAt = m.now().Add(expiration)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.size {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+func (m *Memory) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		if element, ok := m.entries[key]; ok {
+			m.remove(element)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2, 0)
+	require.NoError(t, m.Set(ctx, "a", item{Name: "a"}, time.Hour))
+	require.NoError(t, m.Set(ctx, "b", item{Name: "b"}, time.Hour))
+
+	var got item
+	require.NoError(t, m.Get(ctx, "a", &got))
+	require.NoError(t, m.Set(ctx, "c", item{Name: "c"}, time.Hour))
+
+	require.ErrorIs(t, m.Get(ctx, "b", &got), ErrMiss)
+	require.NoError(t, m.Get(ctx, "a", &got))
+	require.Equal(t, "a", got.Name)
+	require.NoError(t, m.Get(ctx, "c", &got))
+	require.Equal(t, "c", got.Name)
+}
+
+func TestMemory_Expiration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	m := NewMemory(10, time.Minute)
+	m.now = func() time.Time { return now }
+
+	require.NoError(t, m.Set(ctx, "short", item{Name: "short"}, 10*time.Second))
+	// longer expirations are capped at the max TTL
+	require.NoError(t, m.Set(ctx, "long", item{Name: "long"}, time.Hour))
+
+	var got item
+	now = now.Add(30 * time.Second)
+	require.ErrorIs(t, m.Get(ctx, "short", &got), ErrMiss)
+	require.NoError(t, m.Get(ctx, "long", &got))
+
+	now = now.Add(time.Minute)
+	require.ErrorIs(t, m.Get(ctx, "long", &got), ErrMiss)
+}
+
+func TestMemory_Delete(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(10, time.Minute)
+	require.NoError(t, m.Set(ctx, "a", item{Name: "a"}, time.Minute))
+	require.NoError(t, m.Set(ctx, "b", item{Name: "b"}, time.Minute))
+
+	require.NoError(t, m.Delete(ctx, "a", "b", "missing"))
+	var got item
+	require.ErrorIs(t, m.Get(ctx, "a", &got), ErrMiss)
+	require.ErrorIs(t, m.Get(ctx, "b", &got), ErrMiss)
+}
+
+func TestGetOrLoad_WithoutRedis(t *testing.T) {
+	ctx := context.Background()
+	for name, c := range map[string]Cache{"memory": NewMemory(10, time.Minute), "none": Noop{}} {
+		t.Run(name, func(t *testing.T) {
+			aside := NewAside(c, AsideOptions{TTL: time.Hour})
+			var loads int
+			load := func(ctx context.Context) (*item, error) {
+				loads++
+				return &item{Name: "loaded"}, nil
+			}
+			for range 2 {
+				got, err := GetOrLoad(ctx, aside, "item:1", load)
+				require.NoError(t, err)
+				require.Equal(t, "loaded", got.Name)
+			}
+			if name == "memory" {
+				require.Equal(t, 1, loads)
+			} else {
+				require.Equal(t, 2, loads)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	c, err := New(Options{Backend: BackendMemory, MemorySize: 10})
+	require.NoError(t, err)
+	require.IsType(t, &Memory{}, c)
+
+	c, err = New(Options{Backend: BackendNone})
+	require.NoError(t, err)
+	require.IsType(t, Noop{}, c)
+
+	_, err = New(Options{Backend: "memcached"})
+	require.Error(t, err)
+}
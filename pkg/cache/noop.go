@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Noop is a cache that stores nothing, every read is a miss.
+type Noop struct{}
+
+func (Noop) Get(ctx context.Context, key string, dest interface{}) error {
+	return ErrMiss
+}
+
+func (Noop) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return nil
+}
+
+func (Noop) Delete(ctx context.Context, keys ...string) error {
+	return nil
+}
@@ -2,9 +2,15 @@ package cache
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"time"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -12,22 +18,55 @@ type RedisClient struct {
 	Client *redis.Client
 }
 
-func NewRedisClient(redisAddr string) (*RedisClient, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:     redisAddr, // Redis address
-		Password: "",        // no password set
-		DB:       0,         // use default DB
-	})
+type RedisOptions struct {
+	Addr     string
+	Username string
+	Password string
+	DB       int
+	// TLS enables TLS, verified against TLSCAFile if set and the system roots otherwise.
+	TLS           bool
+	TLSServerName string
+	TLSCAFile     string
+}
 
-	ctx := context.Background()
-	_, err := client.Ping(ctx).Result()
-	if err != nil {
-		return nil, err
+// NewRedisClient connects to Redis. An unreachable server is not an error: the client
+// reconnects on its own and the short timeouts keep a dead cache from slowing requests down.
+func NewRedisClient(options RedisOptions) (*RedisClient, error) {
+	redisOptions := &redis.Options{
+		Addr:         options.Addr,
+		Username:     options.Username,
+		Password:     options.Password,
+		DB:           options.DB,
+		DialTimeout:  time.Second,
+		ReadTimeout:  500 * time.Millisecond,
+		WriteTimeout: 500 * time.Millisecond,
+	}
+	if options.TLS {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: options.TLSServerName}
+		if options.TLSCAFile != "" {
+			pem, err := os.ReadFile(options.TLSCAFile)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in %s", options.TLSCAFile)
+			}
+		}
+		redisOptions.TLSConfig = tlsConfig
 	}
 
+	client := redis.NewClient(redisOptions)
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, err
+	}
 	return &RedisClient{Client: client}, nil
 }
 
+func (r *RedisClient) Ping(ctx context.Context) error {
+	return r.Client.Ping(ctx).Err()
+}
+
 func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	jsonBytes, err := json.Marshal(value)
 	if err != nil {
@@ -39,6 +78,9 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, ex
 
 func (r *RedisClient) Get(ctx context.Context, key string, dest interface{}) error {
 	val, err := r.Client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return ErrMiss
+	}
 	if err != nil {
 		return err
 	}
@@ -46,6 +88,13 @@ func (r *RedisClient) Get(ctx context.Context, key string, dest interface{}) err
 	return json.Unmarshal([]byte(val), dest)
 }
 
-func (r *RedisClient) Delete(ctx context.Context, key string) error {
-	return r.Client.Del(ctx, key).Err()
+func (r *RedisClient) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.Client.Del(ctx, keys...).Err()
+}
+
+func (r *RedisClient) Close() error {
+	return r.Client.Close()
 }
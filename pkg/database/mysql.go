@@ -3,26 +3,57 @@ package database
 import (
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
-func NewDatabase(dsn string) (*gorm.DB, error) {
-	gdb, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+type Options struct {
+	DSN             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// Logger receives the statements, GORM's default logger is used when nil.
+	Logger logger.Interface
+	// QueryTimeout and QueryTimeouts configure the QueryTimeoutPlugin.
+	QueryTimeout  time.Duration
+	QueryTimeouts map[string]time.Duration
+}
+
+func NewDatabase(options Options) (*gorm.DB, error) {
+	gdb, err := gorm.Open(mysql.Open(options.DSN), &gorm.Config{
+		Logger: options.Logger,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err = gdb.Use(QueryTimeoutPlugin{Default: options.QueryTimeout, PerOperation: options.QueryTimeouts}); err != nil {
+		return nil, err
+	}
+	if err = gdb.Use(MetricsPlugin{}); err != nil {
+		return nil, err
+	}
+	// statement arguments carry personal data, spans only get the placeholders
+	if err = gdb.Use(tracing.NewPlugin(tracing.WithoutMetrics(), tracing.WithoutQueryVariables())); err != nil {
+		return nil, err
+	}
+
 	db, err := gdb.DB()
 	if err != nil {
 		return nil, err
 	}
-	db.SetMaxIdleConns(10)
-	db.SetMaxOpenConns(25)
-	db.SetConnMaxLifetime(time.Hour)
+	if err = prometheus.Register(collectors.NewDBStatsCollector(db, "hrs")); err != nil {
+		return nil, err
+	}
+	db.SetMaxIdleConns(options.MaxIdleConns)
+	db.SetMaxOpenConns(options.MaxOpenConns)
+	db.SetConnMaxLifetime(options.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(options.ConnMaxIdleTime)
 
 	return gdb, nil
 }
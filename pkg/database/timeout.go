@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const queryCancelKey = "timeout:cancel"
+
+// QueryTimeoutPlugin bounds how long a statement may run. PerOperation is keyed by GORM
+// operation (create, query, update, delete, raw), optionally prefixed with the table, e.g.
+// "day_off_records.query"; Default applies to everything else. Zero means no limit.
+//
+// A statement that fails because its context ended returns an error wrapping
+// context.DeadlineExceeded or context.Canceled.
+type QueryTimeoutPlugin struct {
+	Default      time.Duration
+	PerOperation map[string]time.Duration
+}
+
+func (QueryTimeoutPlugin) Name() string {
+	return "query_timeout"
+}
+
+func (p QueryTimeoutPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	// The deadline has to cover the implicit transaction of writes and outlive the preloads of
+	// queries. Row is left out: its rows are scanned after the callbacks have returned.
+	return errors.Join(
+		callback.Create().Before("gorm:begin_transaction").Register("timeout:before_create", p.start("create")),
+		callback.Create().After("gorm:commit_or_rollback_transaction").Register("timeout:after_create", finish),
+		callback.Query().Before("gorm:query").Register("timeout:before_query", p.start("query")),
+		callback.Query().After("gorm:after_query").Register("timeout:after_query", finish),
+		callback.Update().Before("gorm:begin_transaction").Register("timeout:before_update", p.start("update")),
+		callback.Update().After("gorm:commit_or_rollback_transaction").Register("timeout:after_update", finish),
+		callback.Delete().Before("gorm:begin_transaction").Register("timeout:before_delete", p.start("delete")),
+		callback.Delete().After("gorm:commit_or_rollback_transaction").Register("timeout:after_delete", finish),
+		callback.Raw().Before("gorm:raw").Register("timeout:before_raw", p.start("raw")),
+		callback.Raw().After("gorm:raw").Register("timeout:after_raw", finish),
+	)
+}
+
+func (p QueryTimeoutPlugin) timeout(table, operation string) time.Duration {
+	if timeout, ok := p.PerOperation[table+"."+operation]; ok && table != "" {
+		return timeout
+	}
+	if timeout, ok := p.PerOperation[operation]; ok {
+		return timeout
+	}
+	return p.Default
+}
+
+func (p QueryTimeoutPlugin) start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		timeout := p.timeout(db.Statement.Table, operation)
+		if timeout <= 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(db.Statement.Context, timeout)
+		db.Statement.Context = ctx
+		db.InstanceSet(queryCancelKey, cancel)
+	}
+}
+
+func finish(db *gorm.DB) {
+	// drivers do not always report why a statement was interrupted, make it explicit
+	if db.Error != nil {
+		if ctxErr := db.Statement.Context.Err(); ctxErr != nil && !errors.Is(db.Error, ctxErr) {
+			db.Error = fmt.Errorf("%w: %w", ctxErr, db.Error)
+		}
+	}
+	if value, ok := db.InstanceGet(queryCancelKey); ok {
+		if cancel, ok := value.(context.CancelFunc); ok {
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type employee struct {
+	ID   uint
+	Name string
+}
+
+func TestQueryTimeoutPlugin_Timeout(t *testing.T) {
+	p := QueryTimeoutPlugin{
+		Default: time.Second,
+		PerOperation: map[string]time.Duration{
+			"query":           2 * time.Second,
+			"employees.query": 3 * time.Second,
+			"raw":             0,
+		},
+	}
+	require.Equal(t, 3*time.Second, p.timeout("employees", "query"))
+	require.Equal(t, 2*time.Second, p.timeout("day_off_records", "query"))
+	require.Equal(t, time.Second, p.timeout("employees", "update"))
+	require.Equal(t, time.Duration(0), p.timeout("", "raw"))
+}
+
+func TestQueryTimeoutPlugin_SetsDeadline(t *testing.T) {
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:password@tcp(127.0.0.1:1)/hrs",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	require.NoError(t, gdb.Use(QueryTimeoutPlugin{Default: time.Minute}))
+
+	var deadline time.Time
+	require.NoError(t, gdb.Callback().Query().After("gorm:query").Register("test:deadline", func(db *gorm.DB) {
+		deadline, _ = db.Statement.Context.Deadline()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, gdb.WithContext(ctx).Find(&[]employee{}).Error)
+	require.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+	// the statement context is cancelled once the statement has finished, not the caller's
+	require.NoError(t, ctx.Err())
+	cancel()
+}
+
+func TestFinish_WrapsContextError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	db := &gorm.DB{Statement: &gorm.Statement{Context: ctx}, Error: errors.New("invalid connection")}
+	db.Statement.DB = db
+
+	finish(db)
+	require.ErrorIs(t, db.Error, context.Canceled)
+	require.ErrorContains(t, db.Error, "invalid connection")
+}
@@ -0,0 +1,192 @@
+// Package ical reads the events of iCalendar (RFC 5545) files, as far as holiday calendars need:
+// their summary and dates. Recurring events are not supported.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is a VEVENT. End is exclusive; AllDay events have dates at midnight UTC.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+	AllDay  bool
+}
+
+// Dates returns the calendar days the event touches, in its own time zone, as midnights UTC.
+func (e Event) Dates() []time.Time {
+	last := midnight(e.End)
+	// the end is exclusive, an event ending at midnight does not touch that day
+	if e.End.After(e.Start) && e.End.Hour() == 0 && e.End.Minute() == 0 && e.End.Second() == 0 {
+		last = last.AddDate(0, 0, -1)
+	}
+	var dates []time.Time
+	for day := midnight(e.Start); !day.After(last); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day)
+	}
+	return dates
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Parse reads the events of a calendar. Cancelled events are left out.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var event *Event
+	var cancelled, calendar bool
+	for _, line := range lines {
+		name, params, value, ok := split(line.text)
+		if !ok {
+			return nil, fmt.Errorf("ical: line %d: malformed content line", line.number)
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			calendar = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			if event != nil {
+				return nil, fmt.Errorf("ical: line %d: nested VEVENT", line.number)
+			}
+			event, cancelled = &Event{}, false
+		case event == nil:
+			// calendar properties and other components, such as time zones, are not needed
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("ical: line %d: event without DTSTART", line.number)
+			}
+			if event.End.IsZero() {
+				event.End = event.Start
+				if event.AllDay {
+					event.End = event.Start.AddDate(0, 0, 1)
+				}
+			}
+			if event.End.Before(event.Start) {
+				return nil, fmt.Errorf("ical: line %d: event ends before it starts", line.number)
+			}
+			if !cancelled {
+				events = append(events, *event)
+			}
+			event = nil
+		case name == "UID":
+			event.UID = unescape(value)
+		case name == "SUMMARY":
+			event.Summary = unescape(value)
+		case name == "STATUS":
+			cancelled = strings.EqualFold(value, "CANCELLED")
+		case name == "DTSTART" || name == "DTEND":
+			t, allDay, err := parseTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("ical: line %d: %s: %w", line.number, name, err)
+			}
+			if name == "DTSTART" {
+				event.Start, event.AllDay = t, allDay
+			} else {
+				event.End = t
+			}
+		case name == "RRULE" || name == "RDATE":
+			return nil, fmt.Errorf("ical: line %d: recurring events are not supported", line.number)
+		}
+	}
+	if !calendar {
+		return nil, errors.New("ical: not an iCalendar file")
+	}
+	if event != nil {
+		return nil, errors.New("ical: unterminated VEVENT")
+	}
+	return events, nil
+}
+
+type line struct {
+	number int
+	text   string
+}
+
+// unfold joins the lines continued on the next line by a leading space or tab.
+func unfold(r io.Reader) ([]line, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []line
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if number == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t") {
+			if len(lines) == 0 {
+				return nil, fmt.Errorf("ical: line %d: continuation without a line to continue", number)
+			}
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text != "" {
+			lines = append(lines, line{number: number, text: text})
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// split cuts a content line into its upper case name, its parameters and its value. Parameter
+// values may be quoted and contain colons.
+func split(text string) (name string, params map[string]string, value string, ok bool) {
+	quoted := false
+	colon := -1
+	for i, r := range text {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+	parts := strings.Split(text[:colon], ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, paramValue, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(paramValue, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, text[colon+1:], true
+}
+
+func parseTime(value string, params map[string]string) (time.Time, bool, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	location := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		loaded, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		location = loaded
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	return t, false, err
+}
+
+var unescaper = strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+
+func unescape(value string) string {
+	return unescaper.Replace(value)
+}
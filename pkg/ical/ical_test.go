@@ -0,0 +1,91 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Example//Holidays//EN",
+		"BEGIN:VTIMEZONE",
+		"TZID:Asia/Taipei",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:new-year@example.com",
+		"DTSTART;VALUE=DATE:20250101",
+		"DTEND;VALUE=DATE:20250102",
+		"SUMMARY:New Year\\, the first day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20250127",
+		"DTEND;VALUE=DATE:20250131",
+		"SUMMARY:Lunar New Year holi",
+		" days",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20250228",
+		"SUMMARY:Peace Memorial Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=\"Asia/Taipei\":20250404T000000",
+		"DTEND;TZID=\"Asia/Taipei\":20250405T000000",
+		"SUMMARY:Children's Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20250501",
+		"SUMMARY:Labour Day",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := Parse(strings.NewReader(calendar))
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	require.Equal(t, "new-year@example.com", events[0].UID)
+	require.Equal(t, "New Year, the first day", events[0].Summary)
+	require.True(t, events[0].AllDay)
+	require.Equal(t, []time.Time{date(time.January, 1)}, events[0].Dates())
+
+	require.Equal(t, "Lunar New Year holidays", events[1].Summary)
+	require.Equal(t, []time.Time{date(time.January, 27), date(time.January, 28), date(time.January, 29), date(time.January, 30)}, events[1].Dates())
+
+	// an all day event without an end lasts a day
+	require.Equal(t, []time.Time{date(time.February, 28)}, events[2].Dates())
+
+	require.False(t, events[3].AllDay)
+	require.Equal(t, "Asia/Taipei", events[3].Start.Location().String())
+	require.Equal(t, []time.Time{date(time.April, 4)}, events[3].Dates())
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar string
+		want     string
+	}{
+		{"not a calendar", "name,date\nNew Year,2025-01-01\n", "malformed"},
+		{"no calendar", "VERSION:2.0\n", "not an iCalendar file"},
+		{"recurring", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20250101\nRRULE:FREQ=YEARLY\nEND:VEVENT\nEND:VCALENDAR\n", "recurring"},
+		{"no start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:When\nEND:VEVENT\nEND:VCALENDAR\n", "DTSTART"},
+		{"bad date", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:2025-01-01\nEND:VEVENT\nEND:VCALENDAR\n", "line 3"},
+		{"unterminated", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20250101\n", "unterminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.calendar))
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
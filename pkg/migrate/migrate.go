@@ -0,0 +1,413 @@
+// Package migrate applies versioned SQL migrations to a MySQL database. Applied versions are
+// recorded in a schema version table, and a named lock keeps concurrent runs from racing.
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migration is one schema change. Down is empty for migrations that cannot be reverted.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// fileName matches 0001_create_employees.up.sql and 0001_create_employees.down.sql.
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Load reads the migrations in the root of fsys, ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migrate: unexpected file %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migrate: invalid version in %s", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migrate: version %d is used by %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migrate: version %d has no up migration", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return migrations, nil
+}
+
+// Options configures a Migrator. Table defaults to schema_migrations, LockName to the table
+// name, and LockTimeout, how long to wait for another run to finish, to a minute.
+type Options struct {
+	Table       string
+	LockName    string
+	LockTimeout time.Duration
+	// Logf reports every applied or reverted migration.
+	Logf func(format string, args ...any)
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	options    Options
+}
+
+func New(db *sql.DB, migrations []Migration, options Options) *Migrator {
+	if options.Table == "" {
+		options.Table = "schema_migrations"
+	}
+	if options.LockName == "" {
+		options.LockName = options.Table
+	}
+	if options.LockTimeout == 0 {
+		options.LockTimeout = time.Minute
+	}
+	if options.Logf == nil {
+		options.Logf = func(string, ...any) {}
+	}
+	return &Migrator{db: db, migrations: migrations, options: options}
+}
+
+// ErrDirty is returned when a previous run failed halfway through a migration. MySQL cannot
+// roll back DDL, so the schema has to be repaired by hand and the version set with Force.
+var ErrDirty = errors.New("migrate: database is dirty")
+
+// Status describes a known or applied migration.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Dirty     bool
+	// Unknown marks versions applied to the database that this build has no file for.
+	Unknown bool
+}
+
+type applied struct {
+	name      string
+	appliedAt time.Time
+	dirty     bool
+}
+
+// Up applies every pending migration. Versions applied by a newer build are left alone.
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+	return m.locked(ctx, false, func(conn *sql.Conn, versions map[int64]applied) error {
+		return m.migrate(ctx, conn, plan(m.migrations, versions, m.migrations[len(m.migrations)-1].Version))
+	})
+}
+
+// Down reverts the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.locked(ctx, false, func(conn *sql.Conn, versions map[int64]applied) error {
+		newest := make([]int64, 0, len(versions))
+		for version := range versions {
+			newest = append(newest, version)
+		}
+		// newest first; the version steps down the list is the one to keep
+		slices.SortFunc(newest, func(a, b int64) int { return cmp.Compare(b, a) })
+		var target int64
+		if steps < len(newest) {
+			target = newest[steps]
+		}
+		return m.migrateTo(ctx, conn, versions, target)
+	})
+}
+
+// To migrates up or down to version; 0 reverts every migration.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("migrate: unknown version %d", version)
+	}
+	return m.locked(ctx, false, func(conn *sql.Conn, versions map[int64]applied) error {
+		return m.migrateTo(ctx, conn, versions, version)
+	})
+}
+
+// Force records version as the current, clean state without running any SQL: versions up to
+// it are marked applied and later ones removed. It is the way out of ErrDirty.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("migrate: unknown version %d", version)
+	}
+	return m.locked(ctx, true, func(conn *sql.Conn, versions map[int64]applied) error {
+		if _, err := conn.ExecContext(ctx, "DELETE FROM "+m.table()+" WHERE version > ?", version); err != nil {
+			return err
+		}
+		if _, err := conn.ExecContext(ctx, "UPDATE "+m.table()+" SET dirty = false"); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok || migration.Version > version {
+				continue
+			}
+			if _, err := conn.ExecContext(ctx, "INSERT INTO "+m.table()+" (version, name, dirty, applied_at) VALUES (?, ?, false, ?)",
+				migration.Version, migration.Name, time.Now().UTC()); err != nil {
+				return err
+			}
+		}
+		m.options.Logf("forced version %d", version)
+		return nil
+	})
+}
+
+// Status lists every migration known to this build or applied to the database.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, true, func(conn *sql.Conn, versions map[int64]applied) error {
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if a, ok := versions[migration.Version]; ok {
+				status.Applied, status.AppliedAt, status.Dirty = true, &a.appliedAt, a.dirty
+			}
+			statuses = append(statuses, status)
+		}
+		for version, a := range versions {
+			if !m.known(version) {
+				statuses = append(statuses, Status{Version: version, Name: a.name, Applied: true, AppliedAt: &a.appliedAt, Dirty: a.dirty, Unknown: true})
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return statuses, err
+}
+
+// migrateTo applies or reverts migrations until target is the latest applied version.
+func (m *Migrator) migrateTo(ctx context.Context, conn *sql.Conn, versions map[int64]applied, target int64) error {
+	for version := range versions {
+		if version > target && !m.known(version) {
+			return fmt.Errorf("migrate: version %d was applied by a newer build and cannot be reverted by this one", version)
+		}
+	}
+	return m.migrate(ctx, conn, plan(m.migrations, versions, target))
+}
+
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, steps []step) error {
+	for _, step := range steps {
+		if step.up {
+			if err := m.apply(ctx, conn, step.migration); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := m.revert(ctx, conn, step.migration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type step struct {
+	migration Migration
+	up        bool
+}
+
+// plan returns the migrations to revert, newest first, followed by those to apply, oldest first.
+func plan(migrations []Migration, versions map[int64]applied, target int64) []step {
+	var steps []step
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if _, ok := versions[migrations[i].Version]; ok && migrations[i].Version > target {
+			steps = append(steps, step{migration: migrations[i]})
+		}
+	}
+	for _, migration := range migrations {
+		if _, ok := versions[migration.Version]; !ok && migration.Version <= target {
+			steps = append(steps, step{migration: migration, up: true})
+		}
+	}
+	return steps
+}
+
+// apply marks the version dirty before running it, so that a failure halfway is noticed by the next run.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if _, err := conn.ExecContext(ctx, "INSERT INTO "+m.table()+" (version, name, dirty, applied_at) VALUES (?, ?, true, ?)",
+		migration.Version, migration.Name, time.Now().UTC()); err != nil {
+		return err
+	}
+	if err := execAll(ctx, conn, migration.Up); err != nil {
+		return fmt.Errorf("migrate: %d_%s up: %w", migration.Version, migration.Name, err)
+	}
+	if _, err := conn.ExecContext(ctx, "UPDATE "+m.table()+" SET dirty = false WHERE version = ?", migration.Version); err != nil {
+		return err
+	}
+	m.options.Logf("applied %d_%s", migration.Version, migration.Name)
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if strings.TrimSpace(migration.Down) == "" {
+		return fmt.Errorf("migrate: %d_%s cannot be reverted", migration.Version, migration.Name)
+	}
+	if _, err := conn.ExecContext(ctx, "UPDATE "+m.table()+" SET dirty = true WHERE version = ?", migration.Version); err != nil {
+		return err
+	}
+	if err := execAll(ctx, conn, migration.Down); err != nil {
+		return fmt.Errorf("migrate: %d_%s down: %w", migration.Version, migration.Name, err)
+	}
+	if _, err := conn.ExecContext(ctx, "DELETE FROM "+m.table()+" WHERE version = ?", migration.Version); err != nil {
+		return err
+	}
+	m.options.Logf("reverted %d_%s", migration.Version, migration.Name)
+	return nil
+}
+
+// locked runs fn on a single connection holding the migration lock, with the applied versions.
+// Unless allowDirty is set, it fails with ErrDirty when a previous run broke off.
+func (m *Migrator) locked(ctx context.Context, allowDirty bool, fn func(conn *sql.Conn, versions map[int64]applied) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// MySQL locks belong to the session, so they are taken and released on the same connection
+	var acquired sql.NullInt64
+	if err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", m.options.LockName, int(m.options.LockTimeout.Seconds())).Scan(&acquired); err != nil {
+		return err
+	}
+	if acquired.Int64 != 1 {
+		return fmt.Errorf("migrate: another migration holds the lock %q", m.options.LockName)
+	}
+	defer func() {
+		if _, releaseErr := conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", m.options.LockName); err == nil {
+			err = releaseErr
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+m.table()+` (
+		version bigint NOT NULL PRIMARY KEY,
+		name varchar(255) NOT NULL,
+		dirty boolean NOT NULL,
+		applied_at datetime NOT NULL
+	)`); err != nil {
+		return err
+	}
+	versions, err := m.applied(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if !allowDirty {
+		for version, a := range versions {
+			if a.dirty {
+				return fmt.Errorf("%w: version %d did not complete, repair the schema and run force", ErrDirty, version)
+			}
+		}
+	}
+	return fn(conn, versions)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]applied, error) {
+	// the time is read as text so that it scans the same with and without parseTime in the DSN
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, dirty, CAST(applied_at AS CHAR) FROM "+m.table())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]applied)
+	for rows.Next() {
+		var (
+			version   int64
+			a         applied
+			appliedAt string
+		)
+		if err = rows.Scan(&version, &a.name, &a.dirty, &appliedAt); err != nil {
+			return nil, err
+		}
+		if a.appliedAt, err = time.Parse(time.DateTime, appliedAt); err != nil {
+			return nil, err
+		}
+		versions[version] = a
+	}
+	return versions, rows.Err()
+}
+
+func (m *Migrator) table() string {
+	return "`" + m.options.Table + "`"
+}
+
+func (m *Migrator) known(version int64) bool {
+	return slices.ContainsFunc(m.migrations, func(migration Migration) bool { return migration.Version == version })
+}
+
+// execAll runs the statements of a migration file one by one, so that the DSN does not need
+// multiStatements. Statements end with a semicolon at the end of a line.
+func execAll(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitStatements(script string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+	)
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if current.Len() == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "--")) {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
@@ -0,0 +1,86 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"0002_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON t (a);")},
+		"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (a int);")},
+		"0001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"0010_irreversible.up.sql":   {Data: []byte("UPDATE t SET a = 1;")},
+		"0002_add_index.down.sql":    {Data: []byte("DROP INDEX idx ON t;")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	require.Equal(t, Migration{Version: 1, Name: "create_table", Up: "CREATE TABLE t (a int);", Down: "DROP TABLE t;"}, migrations[0])
+	require.Equal(t, int64(2), migrations[1].Version)
+	require.Equal(t, int64(10), migrations[2].Version)
+	require.Empty(t, migrations[2].Down)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for name, fsys := range map[string]fstest.MapFS{
+		"unexpected file":   {"README.md": {}},
+		"duplicate version": {"0001_a.up.sql": {Data: []byte("SELECT 1;")}, "0001_b.up.sql": {Data: []byte("SELECT 1;")}},
+		"missing up":        {"0001_a.down.sql": {Data: []byte("SELECT 1;")}},
+		"zero version":      {"0000_a.up.sql": {Data: []byte("SELECT 1;")}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 3}, {Version: 4}}
+	versions := func(list ...int64) map[int64]applied {
+		m := make(map[int64]applied)
+		for _, version := range list {
+			m[version] = applied{}
+		}
+		return m
+	}
+	summary := func(steps []step) []int64 {
+		var result []int64
+		for _, s := range steps {
+			if s.up {
+				result = append(result, s.migration.Version)
+			} else {
+				result = append(result, -s.migration.Version)
+			}
+		}
+		return result
+	}
+
+	require.Equal(t, []int64{1, 2, 3, 4}, summary(plan(migrations, versions(), 4)))
+	require.Equal(t, []int64{3, 4}, summary(plan(migrations, versions(1, 2), 4)))
+	require.Equal(t, []int64{-4, -3}, summary(plan(migrations, versions(1, 2, 3, 4), 2)))
+	require.Equal(t, []int64{-4, -3, -2, -1}, summary(plan(migrations, versions(1, 2, 3, 4), 0)))
+	require.Empty(t, plan(migrations, versions(1, 2), 2))
+	// a gap left by a migration merged out of order is filled in
+	require.Equal(t, []int64{2, 4}, summary(plan(migrations, versions(1, 3), 4)))
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- creates the table
+CREATE TABLE t (
+  a varchar(10) DEFAULT 'x;y',
+  b int
+);
+
+-- fills it
+INSERT INTO t (a, b) VALUES ('a', 1);
+UPDATE t SET b = 2`
+
+	require.Equal(t, []string{
+		"CREATE TABLE t (\n  a varchar(10) DEFAULT 'x;y',\n  b int\n);",
+		"INSERT INTO t (a, b) VALUES ('a', 1);",
+		"UPDATE t SET b = 2",
+	}, splitStatements(script))
+}